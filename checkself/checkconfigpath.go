@@ -2,11 +2,13 @@ package checkself
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/senzing-garage/go-cmdhelping/option"
 )
 
+// CheckIDConfigPath identifies the CheckConfigPath check.
+const CheckIDConfigPath = "config-path"
+
 var RequiredConfigFiles = []string{
 	"cfgVariant.json",
 	"defaultGNRCP.config",
@@ -16,33 +18,25 @@ var RequiredConfigFiles = []string{
 // Interface methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) CheckConfigPath(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
+func (checkself *BasicCheckSelf) CheckConfigPath(ctx context.Context, report *Report) error {
 	_ = ctx
 
 	// Short-circuit exit.
 
 	if len(checkself.ConfigPath) == 0 {
-		return reportChecks, reportInfo, reportErrors, nil
+		return nil
 	}
 
 	// Prolog.
 
-	reportChecks = append(
-		reportChecks,
-		fmt.Sprintf("Check configuration path: %s = %s", option.ConfigPath.Envar, checkself.ConfigPath),
-	)
+	report.AddCheck("Check configuration path: %s = %s", option.ConfigPath.Envar, checkself.ConfigPath)
 
 	// Check Config path.
 
-	errorList := statFiles(option.ConfigPath.Envar, checkself.ConfigPath, RequiredConfigFiles)
-	reportErrors = append(reportErrors, errorList...)
+	findings := statFiles(option.ConfigPath.Envar, checkself.ConfigPath, RequiredConfigFiles)
+	report.addFindings(CheckIDConfigPath, findings...)
 
 	// Epilog.
 
-	return reportChecks, reportInfo, reportErrors, nil
+	return nil
 }
@@ -0,0 +1,496 @@
+package checkself
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-helpers/settings"
+	"github.com/senzing-garage/go-helpers/settingsparser"
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// catalogQueries read the live definition of one table.  Each takes the table name bindCount times.
+type catalogQueries struct {
+	bindCount int
+	columns   string // Rows of (column name, data type).
+	indexes   string // Rows of (index name).
+}
+
+// ddlColumn is a column declared in the Senzing schema DDL.
+type ddlColumn struct {
+	name     string
+	dataType string
+}
+
+// ddlTable is a table, and the indexes on it, declared in the Senzing schema DDL.
+type ddlTable struct {
+	columns []ddlColumn
+	indexes []string
+	name    string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CheckIDDatabaseSchemaDrift identifies the CheckDatabaseSchemaDrift check.
+const CheckIDDatabaseSchemaDrift = "database-schema-drift"
+
+// schemaDDLPath is where, under the resource path, the Senzing schema DDL of a dialect is shipped.
+const schemaDDLPath = "schema/szcore-schema-%s-create.sql"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var catalogQueriesByScheme = map[string]catalogQueries{
+	"mssql": {
+		bindCount: 1,
+		columns: `SELECT c.name, t.name FROM sys.columns c JOIN sys.types t ON c.user_type_id = t.user_type_id
+WHERE c.object_id = OBJECT_ID(@p1)`,
+		indexes: `SELECT name FROM sys.indexes WHERE object_id = OBJECT_ID(@p1) AND name IS NOT NULL`,
+	},
+	"mysql": {
+		bindCount: 1,
+		columns: `SELECT COLUMN_NAME, DATA_TYPE FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`,
+		indexes: `SELECT DISTINCT INDEX_NAME FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`,
+	},
+	"oci":    oracleCatalogQueries,
+	"oracle": oracleCatalogQueries,
+	"postgresql": {
+		bindCount: 1,
+		columns: `SELECT column_name, data_type FROM information_schema.columns
+WHERE table_schema = current_schema() AND table_name = lower($1)`,
+		indexes: `SELECT indexname FROM pg_indexes WHERE schemaname = current_schema() AND tablename = lower($1)`,
+	},
+	"sqlite3": {
+		bindCount: 1,
+		columns:   `SELECT name, type FROM pragma_table_info(?)`,
+		indexes:   `SELECT name FROM pragma_index_list(?)`,
+	},
+}
+
+var oracleCatalogQueries = catalogQueries{
+	bindCount: 1,
+	columns:   `SELECT COLUMN_NAME, DATA_TYPE FROM USER_TAB_COLUMNS WHERE TABLE_NAME = :1`,
+	indexes:   `SELECT INDEX_NAME FROM USER_INDEXES WHERE TABLE_NAME = :1`,
+}
+
+var (
+	createIndexRegexp = regexp.MustCompile(
+		`(?is)^\s*CREATE\s+(?:UNIQUE\s+)?(?:(?:NON)?CLUSTERED\s+)?INDEX\s+(\w+)\s+ON\s+(\w+)`,
+	)
+	createTableRegexp = regexp.MustCompile(`(?is)^\s*CREATE\s+TABLE\s+(\w+)\s*\((.*)\)`)
+)
+
+// ddlDialects maps a database URL scheme to the dialect in the name of the schema DDL file.
+var ddlDialects = map[string]string{
+	"mssql":      "mssql",
+	"mysql":      "mysql",
+	"oci":        "oracle",
+	"oracle":     "oracle",
+	"postgresql": "postgresql",
+	"sqlite3":    "sqlite",
+}
+
+// tableConstraintKeywords begin the items of a CREATE TABLE that are not columns.
+var tableConstraintKeywords = []string{"CHECK", "CONSTRAINT", "FOREIGN", "INDEX", "KEY", "PRIMARY", "UNIQUE"}
+
+// typeFamilies groups the data types the dialects use for the same Senzing column.
+var typeFamilies = map[string]string{
+	"BIGINT":    "integer",
+	"BYTE":      "integer",
+	"INT":       "integer",
+	"INTEGER":   "integer",
+	"MEDIUMINT": "integer",
+	"NUMBER":    "integer",
+	"NUMERIC":   "integer",
+	"SMALLINT":  "integer",
+	"TINYINT":   "integer",
+
+	"CHAR":       "text",
+	"CHARACTER":  "text",
+	"CLOB":       "text",
+	"LONGTEXT":   "text",
+	"MEDIUMTEXT": "text",
+	"NVARCHAR":   "text",
+	"TEXT":       "text",
+	"VARCHAR":    "text",
+	"VARCHAR2":   "text",
+
+	"DATE":      "timestamp",
+	"DATETIME":  "timestamp",
+	"DATETIME2": "timestamp",
+	"TIMESTAMP": "timestamp",
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckDatabaseSchemaDrift method compares each database with the Senzing
+schema DDL shipped in the resource path for its dialect
+(e.g. schema/szcore-schema-postgresql-create.sql) and reports missing tables,
+missing columns, columns of a different type family (e.g. text instead of
+integer), and missing indexes.
+*/
+func (checkself *BasicCheckSelf) CheckDatabaseSchemaDrift(ctx context.Context, report *Report) error {
+	resourcePath := checkself.getResourcePath(ctx)
+
+	for _, database := range checkself.getDatabases() {
+		parsedURL, err := ParseDatabaseURL(database.URL)
+		if err != nil || parsedURL.Scheme == "db2" { // Reported by CheckDatabaseURL and CheckDatabaseSchema.
+			continue
+		}
+
+		ddlFile := schemaDDLFile(resourcePath, parsedURL.Scheme)
+
+		report.AddCheck("Check database schema drift for %s: %s against %s", database.Name, database.URL, ddlFile)
+		report.addDatabaseFindings(
+			CheckIDDatabaseSchemaDrift,
+			database,
+			checkDatabaseSchemaDrift(ctx, database, parsedURL.Scheme, ddlFile)...,
+		)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// getResourcePath returns ResourcePath or, if not set, PIPELINE.RESOURCEPATH of the settings.
+func (checkself *BasicCheckSelf) getResourcePath(ctx context.Context) string {
+	if len(checkself.ResourcePath) > 0 {
+		return checkself.ResourcePath
+	}
+
+	var err error
+
+	engineSettings := checkself.Settings
+	if len(engineSettings) == 0 {
+		engineSettings, err = settings.BuildSimpleSettingsUsingEnvVars()
+		if err != nil {
+			return "" // Reported by CheckSettings.
+		}
+	}
+
+	parsedSettings := &settingsparser.BasicSettingsParser{
+		Settings: engineSettings,
+	}
+
+	result, err := parsedSettings.GetResourcePath(ctx)
+	if err != nil {
+		return "" // Reported by CheckSettings.
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func checkDatabaseSchemaDrift(ctx context.Context, database Database, scheme string, ddlFile string) []Finding {
+	var result []Finding
+
+	ddl, err := os.ReadFile(ddlFile)
+	if err != nil {
+		return append(result, newWarning(
+			option.ResourcePath.Envar,
+			"",
+			"Could not read the Senzing schema DDL "+ddlFile+"; schema drift was not checked.",
+			err,
+		))
+	}
+
+	databaseConnector, err := newDatabaseConnector(ctx, database.URL)
+	if err != nil {
+		return append(result, newError(database.Variable, database.URL, "Could not create a database connector.", err))
+	}
+
+	sqlDB := sql.OpenDB(databaseConnector)
+	defer sqlDB.Close()
+
+	queries := catalogQueriesByScheme[scheme]
+
+	for _, table := range parseSchemaDDL(string(ddl)) {
+		findings, err := compareTable(ctx, sqlDB, queries, table)
+		if err != nil {
+			return append(result, newError(
+				database.Variable,
+				"",
+				"Could not read the definition of "+table.name+".",
+				err,
+			))
+		}
+
+		for _, finding := range findings {
+			result = append(result, newError(database.Variable, "", finding, nil))
+		}
+	}
+
+	return result
+}
+
+// compareTable returns a message for each difference between a table in the DDL and in the database.
+func compareTable(ctx context.Context, sqlDB *sql.DB, queries catalogQueries, table ddlTable) ([]string, error) {
+	var result []string
+
+	columnRows, err := queryCatalog(ctx, sqlDB, queries.columns, table.name, queries.bindCount)
+	if err != nil {
+		return result, wraperror.Errorf(err, "columns")
+	}
+
+	if len(columnRows) == 0 {
+		return append(result, "Table "+table.name+" is missing."), nil
+	}
+
+	liveColumns := map[string]string{}
+	for _, row := range columnRows {
+		liveColumns[strings.ToUpper(row[0])] = row[1]
+	}
+
+	for _, column := range table.columns {
+		liveType, isOK := liveColumns[column.name]
+
+		switch {
+		case !isOK:
+			result = append(result, "Column "+table.name+"."+column.name+" is missing.")
+		case typeFamily(liveType) != typeFamily(column.dataType):
+			result = append(result, fmt.Sprintf("Column %s.%s has type %s; the schema expects %s.",
+				table.name, column.name, strings.ToUpper(liveType), column.dataType))
+		}
+	}
+
+	indexRows, err := queryCatalog(ctx, sqlDB, queries.indexes, table.name, queries.bindCount)
+	if err != nil {
+		return result, wraperror.Errorf(err, "indexes")
+	}
+
+	liveIndexes := map[string]bool{}
+	for _, row := range indexRows {
+		liveIndexes[strings.ToUpper(row[0])] = true
+	}
+
+	for _, index := range table.indexes {
+		if !liveIndexes[index] {
+			result = append(result, "Index "+index+" on "+table.name+" is missing.")
+		}
+	}
+
+	return result, nil
+}
+
+/*
+The parseSchemaDDL function extracts the tables, their columns, and the
+indexes from a Senzing schema DDL file.  See splitSchemaDDL.
+INSERTs and ALTERs are ignored.
+Names and types are upper-cased.
+*/
+func parseSchemaDDL(ddl string) []ddlTable {
+	var result []ddlTable
+
+	tableIndex := map[string]int{}
+
+	for _, statement := range splitSchemaDDL(ddl) {
+		if match := createTableRegexp.FindStringSubmatch(statement); match != nil {
+			name := strings.ToUpper(match[1])
+			tableIndex[name] = len(result)
+			result = append(result, ddlTable{columns: parseColumns(match[2]), indexes: nil, name: name})
+
+			continue
+		}
+
+		if match := createIndexRegexp.FindStringSubmatch(statement); match != nil {
+			position, isOK := tableIndex[strings.ToUpper(match[2])]
+			if isOK {
+				result[position].indexes = append(result[position].indexes, strings.ToUpper(match[1]))
+			}
+		}
+	}
+
+	return result
+}
+
+// parseColumns extracts the columns from the body of a CREATE TABLE statement.
+func parseColumns(body string) []ddlColumn {
+	var (
+		depth  int
+		items  []string
+		result []ddlColumn
+		start  int
+	)
+
+	for position, character := range body {
+		switch character {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, body[start:position])
+				start = position + 1
+			}
+		}
+	}
+
+	items = append(items, body[start:])
+
+	for _, item := range items {
+		fields := strings.Fields(strings.ToUpper(item))
+		if len(fields) < 2 || isTableConstraint(fields[0]) {
+			continue
+		}
+
+		result = append(result, ddlColumn{name: fields[0], dataType: fields[1]})
+	}
+
+	return result
+}
+
+// queryCatalog runs a catalog query about a table, returning each row as strings.
+func queryCatalog(ctx context.Context, sqlDB *sql.DB, query string, table string, bindCount int) ([][]string, error) {
+	var result [][]string
+
+	arguments := make([]any, bindCount)
+	for index := range arguments {
+		arguments[index] = table
+	}
+
+	rows, err := sqlDB.QueryContext(ctx, query, arguments...)
+	if err != nil {
+		return result, wraperror.Errorf(err, "query %s", table)
+	}
+
+	defer rows.Close()
+
+	columnNames, err := rows.Columns()
+	if err != nil {
+		return result, wraperror.Errorf(err, "columns of %s", table)
+	}
+
+	for rows.Next() {
+		values := make([]sql.NullString, len(columnNames))
+
+		pointers := make([]any, len(values))
+		for index := range values {
+			pointers[index] = &values[index]
+		}
+
+		err = rows.Scan(pointers...)
+		if err != nil {
+			return result, wraperror.Errorf(err, "scan %s", table)
+		}
+
+		row := make([]string, len(values))
+		for index, value := range values {
+			row[index] = value.String
+		}
+
+		result = append(result, row)
+	}
+
+	return result, wraperror.Errorf(rows.Err(), wraperror.NoMessage)
+}
+
+// isTableConstraint reports whether the first word of a CREATE TABLE item begins a constraint, not a column.
+func isTableConstraint(word string) bool {
+	for _, keyword := range tableConstraintKeywords {
+		if word == keyword || strings.HasPrefix(word, keyword+"(") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// schemaDDLFile returns the path of the Senzing schema DDL for the dialect of a database URL scheme.
+func schemaDDLFile(resourcePath string, scheme string) string {
+	return filepath.Join(resourcePath, fmt.Sprintf(schemaDDLPath, ddlDialects[scheme]))
+}
+
+/*
+The splitSchemaDDL function returns the statements of a Senzing schema DDL
+file, without "--" comments.  Statements end in ";", which may span lines.
+A DDL file with no ";" (e.g. MSSQL) has one statement per line.
+*/
+func splitSchemaDDL(ddl string) []string {
+	var (
+		isQuoted   bool
+		result     []string
+		statement  strings.Builder
+		statements []string
+	)
+
+	for index := 0; index < len(ddl); index++ {
+		switch {
+		case !isQuoted && strings.HasPrefix(ddl[index:], "--"):
+			end := strings.IndexByte(ddl[index:], '\n')
+			if end < 0 {
+				end = len(ddl) - index
+			}
+
+			index += end - 1 // Keep the newline.
+
+			continue
+		case !isQuoted && ddl[index] == ';':
+			statements = append(statements, statement.String())
+			statement.Reset()
+
+			continue
+		case ddl[index] == '\'':
+			isQuoted = !isQuoted
+		}
+
+		statement.WriteByte(ddl[index])
+	}
+
+	if len(statements) == 0 {
+		statements = strings.Split(statement.String(), "\n")
+	} else {
+		statements = append(statements, statement.String())
+	}
+
+	for _, statement := range statements {
+		statement = strings.TrimSpace(statement)
+		if len(statement) > 0 {
+			result = append(result, statement)
+		}
+	}
+
+	return result
+}
+
+// typeFamily returns the family of a data type (e.g. "VARCHAR(40)" and "character varying" are "text").
+func typeFamily(dataType string) string {
+	baseType := strings.ToUpper(strings.TrimSpace(dataType))
+	baseType, _, _ = strings.Cut(baseType, "(")
+
+	fields := strings.Fields(baseType)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	result, isOK := typeFamilies[fields[0]]
+	if !isOK {
+		return fields[0]
+	}
+
+	return result
+}
@@ -0,0 +1,319 @@
+package checkself
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	defaultDialTimeout  = 5 * time.Second
+	postgresSSLRequest  = 80877103 // See https://www.postgresql.org/docs/current/protocol-message-formats.html
+	postgresSSLAccepted = 'S'
+	postgresSSLRejected = 'N'
+	sslRequestLength    = 8
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Default port of each network database scheme.
+var defaultPorts = map[string]string{
+	"db2":        "50000",
+	"mssql":      "1433",
+	"mysql":      "3306",
+	"oci":        "1521",
+	"oracle":     "1521",
+	"postgresql": "5432",
+}
+
+// Lower-case fragments of driver errors that mean the user name or password was rejected.
+var authenticationFailures = []string{
+	"28p01",                          // PostgreSQL invalid_password.
+	"access denied for user",         // MySQL 1045.
+	"login failed for user",          // MSSQL 18456.
+	"ora-01017",                      // Oracle invalid username/password.
+	"password authentication failed", // PostgreSQL.
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+/*
+The probeNetwork function checks connectivity to a network database one stage
+at a time: DNS resolution of the host, TCP connection to the port, and, for
+PostgreSQL and MySQL, the TLS handshake.  MSSQL negotiates encryption within
+its TDS pre-login exchange, which is not probed, so MSSQL URLs get no TLS
+stage.  Authentication is checked afterwards by checkDatabaseConnection.
+
+Each stage that passes is reported as a SeverityInfo finding with its timing;
+the first stage that fails is reported as SeverityCritical and ends the probe.
+Net service names and aliases (Oracle and DB2 URLs without a port) are not probed.
+*/
+func probeNetwork(ctx context.Context, variableName string, parsedURL *ParsedDatabaseURL) []Finding {
+	var result []Finding
+
+	port := parsedURL.Port
+
+	if len(port) == 0 {
+		if parsedURL.Scheme == "oci" || parsedURL.Scheme == "oracle" || parsedURL.Scheme == "db2" {
+			return result // Short-circuit exit.
+		}
+
+		port = defaultPorts[parsedURL.Scheme]
+	}
+
+	// Stage 1: DNS.
+
+	finding := probeDNS(ctx, variableName, parsedURL.Host)
+	if finding != nil {
+		result = append(result, *finding)
+		if finding.Severity >= SeverityError {
+			return result
+		}
+	}
+
+	// Stage 2: TCP.
+
+	address := net.JoinHostPort(parsedURL.Host, port)
+
+	connection, finding := probeTCP(ctx, variableName, address)
+	result = append(result, *finding)
+
+	if connection == nil {
+		return result
+	}
+
+	defer connection.Close()
+
+	// Stage 3: TLS.
+
+	switch parsedURL.Scheme {
+	case "mysql":
+		finding = probeMySQLTLS(connection, variableName, parsedURL)
+	case "postgresql":
+		finding = probePostgresTLS(connection, variableName, parsedURL)
+	default:
+		finding = nil
+	}
+
+	if finding != nil {
+		result = append(result, *finding)
+	}
+
+	return result
+}
+
+// probeDNS resolves host.  IP addresses need no resolution, so nil is returned for them.
+func probeDNS(ctx context.Context, variableName string, host string) *Finding {
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultDialTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	addresses, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		var dnsError *net.DNSError
+
+		message := fmt.Sprintf("DNS: could not resolve host name '%s'.", host)
+		if errors.As(err, &dnsError) && dnsError.IsNotFound {
+			message = fmt.Sprintf("DNS: host name '%s' not found.", host)
+		}
+
+		finding := newCritical(variableName, "", message, err)
+
+		return &finding
+	}
+
+	finding := newInfo(variableName, fmt.Sprintf(
+		"DNS: %s resolved to %s in %s.",
+		host,
+		strings.Join(addresses, ", "),
+		elapsed(start),
+	))
+
+	return &finding
+}
+
+/*
+The probeMySQLTLS function reads the greeting of a MySQL server and, if the tls
+option asks for TLS, requests it and performs the handshake.  As for
+PostgreSQL, only the handshake is probed, not the certificate.
+*/
+func probeMySQLTLS(connection net.Conn, variableName string, parsedURL *ParsedDatabaseURL) *Finding {
+	var finding Finding
+
+	settings := mysqlTLSSettings(parsedURL)
+	if !settings.enabled {
+		return nil
+	}
+
+	start := time.Now()
+	_ = connection.SetDeadline(start.Add(defaultDialTimeout))
+
+	isSupported, err := startTLS(connection, parsedURL.Scheme)
+
+	switch {
+	case err != nil:
+		finding = newCritical(variableName, "", "TLS: could not negotiate TLS with the server.", err)
+	case !isSupported && !settings.required:
+		finding = newWarning(
+			variableName,
+			"",
+			"TLS: server does not support TLS; the connection is not encrypted.",
+			nil,
+		)
+	case !isSupported:
+		finding = newCritical(variableName, "", "TLS: server does not support TLS, but "+settings.option+".", nil)
+	default:
+		finding = handshakeTLS(connection, variableName, parsedURL.Host, start)
+	}
+
+	return &finding
+}
+
+/*
+The probePostgresTLS function asks a PostgreSQL server to switch to TLS and
+performs the handshake, honoring sslmode (default: "require", as in lib/pq).
+The certificate is not verified here; only the handshake is.
+*/
+func probePostgresTLS(connection net.Conn, variableName string, parsedURL *ParsedDatabaseURL) *Finding {
+	var finding Finding
+
+	sslMode := parsedURL.Options["sslmode"]
+	if sslMode == "disable" {
+		return nil
+	}
+
+	if len(sslMode) == 0 {
+		sslMode = "require"
+	}
+
+	start := time.Now()
+	_ = connection.SetDeadline(start.Add(defaultDialTimeout))
+
+	answer, err := requestPostgresTLS(connection)
+
+	switch {
+	case err != nil:
+		finding = newCritical(variableName, "", "TLS: could not negotiate TLS with the server.", err)
+	case answer == postgresSSLRejected && (sslMode == "allow" || sslMode == "prefer"):
+		finding = newWarning(
+			variableName,
+			"",
+			"TLS: server does not support TLS; the connection is not encrypted.",
+			nil,
+		)
+	case answer == postgresSSLRejected:
+		finding = newCritical(variableName, "", "TLS: server does not support TLS, but sslmode="+sslMode+".", nil)
+	case answer != postgresSSLAccepted:
+		finding = newCritical(variableName, "", fmt.Sprintf("TLS: unexpected answer %q to SSLRequest.", answer), nil)
+	default:
+		finding = handshakeTLS(connection, variableName, parsedURL.Host, start)
+	}
+
+	return &finding
+}
+
+func handshakeTLS(connection net.Conn, variableName string, host string, start time.Time) Finding {
+	tlsConnection := tls.Client(connection, &tls.Config{
+		InsecureSkipVerify: true, // #nosec G402 -- Only the handshake is probed; no data is exchanged.
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         host,
+	})
+
+	err := tlsConnection.Handshake()
+	if err != nil {
+		return newCritical(variableName, "", "TLS: handshake failed.", err)
+	}
+
+	return newInfo(variableName, fmt.Sprintf(
+		"TLS: handshake completed using %s in %s.",
+		tls.VersionName(tlsConnection.ConnectionState().Version),
+		elapsed(start),
+	))
+}
+
+// requestPostgresTLS sends a PostgreSQL SSLRequest and returns the server's one byte answer ('S' or 'N').
+func requestPostgresTLS(connection net.Conn) (byte, error) {
+	request := make([]byte, sslRequestLength)
+	binary.BigEndian.PutUint32(request[0:4], sslRequestLength)
+	binary.BigEndian.PutUint32(request[4:8], postgresSSLRequest)
+
+	answer := make([]byte, 1)
+
+	_, err := connection.Write(request)
+	if err == nil {
+		_, err = io.ReadFull(connection, answer)
+	}
+
+	return answer[0], wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// probeTCP connects to address.  On success, the open connection is returned for later stages.
+func probeTCP(ctx context.Context, variableName string, address string) (net.Conn, *Finding) {
+	var (
+		dialer    net.Dialer
+		netError  net.Error
+		finding   Finding
+		errorText string
+	)
+
+	dialer.Timeout = defaultDialTimeout
+	start := time.Now()
+
+	connection, err := dialer.DialContext(ctx, "tcp", address)
+
+	switch {
+	case err == nil:
+		finding = newInfo(variableName, fmt.Sprintf("TCP: connected to %s in %s.", address, elapsed(start)))
+
+		return connection, &finding
+	case errors.Is(err, syscall.ECONNREFUSED):
+		errorText = fmt.Sprintf("TCP: connection to %s refused.", address)
+	case errors.As(err, &netError) && netError.Timeout():
+		errorText = fmt.Sprintf("TCP: connection to %s timed out after %s.", address, elapsed(start))
+	default:
+		errorText = fmt.Sprintf("TCP: could not connect to %s.", address)
+	}
+
+	finding = newCritical(variableName, "", errorText, err)
+
+	return nil, &finding
+}
+
+// isAuthenticationFailure reports whether a driver error means the credentials were rejected.
+func isAuthenticationFailure(err error) bool {
+	text := strings.ToLower(err.Error())
+
+	for _, failure := range authenticationFailures {
+		if strings.Contains(text, failure) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func elapsed(start time.Time) time.Duration {
+	return time.Since(start).Round(time.Microsecond)
+}
@@ -0,0 +1,237 @@
+package checkself
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// privilegeLister returns which of requiredTablePrivileges the connected user holds on a table.
+type privilegeLister func(ctx context.Context, database *sql.DB, table string) ([]string, error)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CheckIDDatabasePrivileges identifies the CheckDatabasePrivileges check.
+const CheckIDDatabasePrivileges = "database-privileges"
+
+// Catalog queries.  Each lists the privileges, of SELECT, INSERT, UPDATE, and DELETE, held on one table.
+const (
+	mssqlPrivilegesSQL = `SELECT p.name FROM (VALUES ('SELECT'), ('INSERT'), ('UPDATE'), ('DELETE')) AS p(name)
+WHERE HAS_PERMS_BY_NAME(@p1, 'OBJECT', p.name) = 1`
+
+	mysqlGrantee       = `CONCAT('''', REPLACE(CURRENT_USER(), '@', '''@'''), '''')`
+	mysqlPrivilegesSQL = `SELECT PRIVILEGE_TYPE FROM information_schema.USER_PRIVILEGES
+WHERE GRANTEE = ` + mysqlGrantee + `
+UNION SELECT PRIVILEGE_TYPE FROM information_schema.SCHEMA_PRIVILEGES
+WHERE GRANTEE = ` + mysqlGrantee + ` AND TABLE_SCHEMA = DATABASE()
+UNION SELECT PRIVILEGE_TYPE FROM information_schema.TABLE_PRIVILEGES
+WHERE GRANTEE = ` + mysqlGrantee + ` AND TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`
+
+	oraclePrivilegesSQL = `SELECT p.privilege FROM (
+SELECT 'SELECT' AS privilege FROM DUAL UNION ALL SELECT 'INSERT' FROM DUAL
+UNION ALL SELECT 'UPDATE' FROM DUAL UNION ALL SELECT 'DELETE' FROM DUAL) p
+WHERE EXISTS (SELECT 1 FROM USER_TABLES WHERE TABLE_NAME = :1)
+OR EXISTS (SELECT 1 FROM ALL_TAB_PRIVS t WHERE t.TABLE_NAME = :2 AND t.PRIVILEGE = p.privilege
+AND (t.GRANTEE IN (USER, 'PUBLIC') OR t.GRANTEE IN (SELECT ROLE FROM SESSION_ROLES)))`
+
+	postgresqlPrivilegesSQL = `SELECT privilege FROM unnest(ARRAY['SELECT', 'INSERT', 'UPDATE', 'DELETE']) AS privilege
+WHERE has_table_privilege(current_user, $1, privilege)`
+)
+
+// Catalog queries returning 1 if the connected user can create temporary tables.
+const (
+	mysqlTemporarySQL = `SELECT COUNT(*) FROM (
+SELECT PRIVILEGE_TYPE FROM information_schema.USER_PRIVILEGES WHERE GRANTEE = ` + mysqlGrantee + `
+UNION SELECT PRIVILEGE_TYPE FROM information_schema.SCHEMA_PRIVILEGES
+WHERE GRANTEE = ` + mysqlGrantee + ` AND TABLE_SCHEMA = DATABASE()) AS privileges
+WHERE PRIVILEGE_TYPE = 'CREATE TEMPORARY TABLES'`
+
+	postgresqlTemporarySQL = `SELECT CASE WHEN has_database_privilege(current_database(), 'TEMPORARY') THEN 1 ELSE 0 END`
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// SenzingCoreTables are the tables of the Senzing core schema.
+var SenzingCoreTables = []string{
+	"DSRC_RECORD",
+	"LIB_FEAT",
+	"OBS_ENT",
+	"RES_ENT",
+	"RES_ENT_OKEY",
+	"RES_FEAT_EKEY",
+	"RES_FEAT_STAT",
+	"RES_RELATE",
+	"RES_REL_EKEY",
+	"SYS_CFG",
+	"SYS_CODES_USED",
+	"SYS_EVAL_QUEUE",
+	"SYS_HW_CHECK",
+	"SYS_SEQUENCE",
+	"SYS_STATUS",
+	"SYS_VARS",
+}
+
+var privilegeListers = map[string]privilegeLister{
+	"mssql":      newPrivilegeLister(mssqlPrivilegesSQL, 1),
+	"mysql":      newPrivilegeLister(mysqlPrivilegesSQL, 1),
+	"oci":        newPrivilegeLister(oraclePrivilegesSQL, 2),
+	"oracle":     newPrivilegeLister(oraclePrivilegesSQL, 2),
+	"postgresql": newPrivilegeLister(postgresqlPrivilegesSQL, 1),
+}
+
+var requiredTablePrivileges = []string{"SELECT", "INSERT", "UPDATE", "DELETE"}
+
+var temporaryPrivilegeQueries = map[string]string{
+	"mysql":      mysqlTemporarySQL,
+	"postgresql": postgresqlTemporarySQL,
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckDatabasePrivileges method verifies the database user can SELECT,
+INSERT, UPDATE, and DELETE on every Senzing core table and, for PostgreSQL and
+MySQL, create temporary tables.  Missing grants are listed table by table.
+SQLite has no grants; see CheckDatabaseSQLite for file permissions.
+*/
+func (checkself *BasicCheckSelf) CheckDatabasePrivileges(ctx context.Context, report *Report) error {
+	for _, database := range checkself.getDatabases() {
+		parsedURL, err := ParseDatabaseURL(database.URL)
+		if err != nil || parsedURL.Scheme == "db2" || parsedURL.Scheme == "sqlite3" { // See CheckDatabaseSQLite.
+			continue
+		}
+
+		report.AddCheck("Check database privileges for %s: %s", database.Name, database.URL)
+		report.addDatabaseFindings(
+			CheckIDDatabasePrivileges,
+			database,
+			checkDatabasePrivileges(ctx, database, parsedURL)...,
+		)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func checkDatabasePrivileges(ctx context.Context, database Database, parsedURL *ParsedDatabaseURL) []Finding {
+	var result []Finding
+
+	databaseConnector, err := newDatabaseConnector(ctx, database.URL)
+	if err != nil {
+		return append(result, newError(database.Variable, database.URL, "Could not create a database connector.", err))
+	}
+
+	sqlDB := sql.OpenDB(databaseConnector)
+	defer sqlDB.Close()
+
+	// Check table privileges.
+
+	listPrivileges := privilegeListers[parsedURL.Scheme]
+
+	for _, table := range SenzingCoreTables {
+		granted, err := listPrivileges(ctx, sqlDB, table)
+		if err != nil {
+			return append(result, newError(database.Variable, "", "Could not read privileges on "+table+".", err))
+		}
+
+		missing := slices.DeleteFunc(slices.Clone(requiredTablePrivileges), func(privilege string) bool {
+			return slices.Contains(granted, privilege)
+		})
+
+		if len(missing) > 0 {
+			grants := strings.Join(missing, ", ")
+			result = append(result, newError(database.Variable, "", "Missing grants on "+table+": "+grants+
+				". For example: GRANT "+grants+" ON "+table+" TO "+parsedURL.User+";", nil))
+		}
+	}
+
+	// Check temporary table privilege.
+
+	return append(result, checkTemporaryPrivilege(ctx, sqlDB, database.Variable, parsedURL)...)
+}
+
+func checkTemporaryPrivilege(
+	ctx context.Context,
+	sqlDB *sql.DB,
+	variableName string,
+	parsedURL *ParsedDatabaseURL,
+) []Finding {
+	var (
+		canCreate int
+		result    []Finding
+	)
+
+	query, isOK := temporaryPrivilegeQueries[parsedURL.Scheme]
+	if !isOK {
+		return result
+	}
+
+	err := sqlDB.QueryRowContext(ctx, query).Scan(&canCreate)
+	if err != nil {
+		return append(result, newError(
+			variableName,
+			"",
+			"Could not read the privilege to create temporary tables.",
+			err,
+		))
+	}
+
+	if canCreate == 0 {
+		result = append(result, newError(
+			variableName,
+			"",
+			"User '"+parsedURL.User+"' cannot create temporary tables.",
+			nil,
+		))
+	}
+
+	return result
+}
+
+// newPrivilegeLister makes a privilegeLister from a catalog query that takes the table name bindCount times.
+func newPrivilegeLister(query string, bindCount int) privilegeLister {
+	return func(ctx context.Context, database *sql.DB, table string) ([]string, error) {
+		var result []string
+
+		arguments := make([]any, bindCount)
+		for index := range arguments {
+			arguments[index] = table
+		}
+
+		rows, err := database.QueryContext(ctx, query, arguments...)
+		if err != nil {
+			return result, wraperror.Errorf(err, "query privileges on %s", table)
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var privilege string
+
+			err = rows.Scan(&privilege)
+			if err != nil {
+				return result, wraperror.Errorf(err, "scan privileges on %s", table)
+			}
+
+			result = append(result, strings.ToUpper(privilege))
+		}
+
+		return result, wraperror.Errorf(rows.Err(), wraperror.NoMessage)
+	}
+}
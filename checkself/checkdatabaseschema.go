@@ -2,46 +2,56 @@ package checkself
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/senzing-garage/go-cmdhelping/option"
 	"github.com/senzing-garage/go-databasing/checker"
-	"github.com/senzing-garage/go-databasing/connector"
 )
 
+// CheckIDDatabaseSchema identifies the CheckDatabaseSchema check.
+const CheckIDDatabaseSchema = "database-schema"
+
 // ----------------------------------------------------------------------------
 // Interface methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) CheckDatabaseSchema(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
-	if len(checkself.DatabaseURL) == 0 { // Short-circuit exit.
-		return reportChecks, reportInfo, reportErrors, nil
+func (checkself *BasicCheckSelf) CheckDatabaseSchema(ctx context.Context, report *Report) error {
+	for _, database := range checkself.getDatabases() {
+		report.AddCheck("Check database schema for %s: %s", database.Name, database.URL)
+		report.addDatabaseFindings(CheckIDDatabaseSchema, database, checkDatabaseSchema(ctx, database, report)...)
 	}
 
-	// Prolog.
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// checkDatabaseSchema verifies the Senzing schema is installed in a database and reports its record count.
+func checkDatabaseSchema(ctx context.Context, database Database, report *Report) []Finding {
+	var result []Finding
 
-	reportChecks = append(reportChecks, "Check database schema for "+checkself.DatabaseURL)
+	// Short-circuit exit.
+
+	parsedURL, err := ParseDatabaseURL(database.URL)
+	if err == nil && parsedURL.Scheme == "db2" { // Malformed URLs are reported by newDatabaseConnector.
+		return append(result, newWarning(
+			database.Variable,
+			"",
+			"Could not check the Senzing database schema: no DB2 driver is available.",
+			nil,
+		))
+	}
 
 	// Connect to the database.
 
-	databaseConnector, err := connector.NewConnector(ctx, checkself.DatabaseURL)
+	databaseConnector, err := newDatabaseConnector(ctx, database.URL)
 	if err != nil {
-		reportErrors = append(
-			reportErrors,
-			fmt.Sprintf(
-				"%s = %s is misconfigured. Could not create a database connector. For more information, visit https://hub.senzing.com/...  Error: %s",
-				option.DatabaseURL.Envar,
-				checkself.DatabaseURL,
-				err.Error(),
-			),
-		)
-
-		return reportChecks, reportInfo, reportErrors, nil
+		return append(result, newError(
+			database.Variable,
+			database.URL,
+			"Could not create a database connector.",
+			err,
+		))
 	}
 
 	// Check for Senzing database schema.
@@ -52,17 +62,23 @@ func (checkself *BasicCheckSelf) CheckDatabaseSchema(
 
 	isSchemaInstalled, err := checker.IsSchemaInstalled(ctx)
 	if !isSchemaInstalled {
-		reportErrors = append(
-			reportErrors,
-			fmt.Sprintf(
-				"Senzing database schema has not been installed in %s. For more information, visit https://hub.senzing.com/...  Error: %s",
-				checkself.DatabaseURL,
-				err.Error(),
-			),
-		)
+		return append(result, newError(
+			database.Variable,
+			"",
+			"Senzing database schema has not been installed in "+database.URL+
+				". To install it, run \"check-self fix schema\".",
+			err,
+		))
+	}
+
+	// Count records.
+
+	recordCount, err := checker.RecordCount(ctx)
+	if err != nil {
+		return append(result, newError(database.Variable, "", "Could not get count of records.", err))
 	}
 
-	// Epilog.
+	report.AddInfo("Database %s: %d records", database.Name, recordCount)
 
-	return reportChecks, reportInfo, reportErrors, nil
+	return result
 }
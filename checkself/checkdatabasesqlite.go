@@ -0,0 +1,322 @@
+package checkself
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-databasing/dbhelper"
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CheckIDDatabaseSQLite identifies the CheckDatabaseSQLite check.
+const CheckIDDatabaseSQLite = "database-sqlite"
+
+const (
+	maxIntegrityMessages = 5               // Problems listed from PRAGMA integrity_check.
+	staleJournalAge      = 1 * time.Minute // A rollback journal older than this outlived its transaction.
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckDatabaseSQLite method looks deeper at each SQLite database: whether the
+file and its directory are writable by the running UID, PRAGMA quick_check and
+integrity_check, journal mode, page size and file size, stale -journal and -wal
+files, whether another process holds a lock, and whether the file is in a
+temporary directory or on a network filesystem.
+*/
+func (checkself *BasicCheckSelf) CheckDatabaseSQLite(ctx context.Context, report *Report) error {
+	for _, database := range checkself.getDatabases() {
+		parsedURL, err := ParseDatabaseURL(database.URL)
+		if err != nil || parsedURL.Scheme != "sqlite3" { // Reported by CheckDatabaseURL, or not SQLite.
+			continue
+		}
+
+		sqliteFilename, err := dbhelper.ExtractSqliteDatabaseFilename(database.URL)
+		if err != nil {
+			continue // Reported by CheckDatabaseURL.
+		}
+
+		report.AddCheck("Check SQLite database for %s: %s", database.Name, sqliteFilename)
+		report.addDatabaseFindings(
+			CheckIDDatabaseSQLite,
+			database,
+			checkSqliteHealth(ctx, database, sqliteFilename)...,
+		)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// checkSqliteHealth runs the SQLite checks on one database file.
+func checkSqliteHealth(ctx context.Context, database Database, sqliteFilename string) []Finding {
+	result := checkSqliteLocation(database.Variable, sqliteFilename)
+	result = append(result, checkSqliteWritable(database.Variable, sqliteFilename)...)
+
+	// Look for journals before opening the database, which would roll back a hot journal.
+
+	journalInfo, journalErr := os.Stat(sqliteFilename + "-journal")
+	_, walErr := os.Stat(sqliteFilename + "-wal")
+
+	databaseConnector, err := newDatabaseConnector(ctx, database.URL)
+	if err != nil {
+		return append(result, newError(database.Variable, database.URL, "Could not create a database connector.", err))
+	}
+
+	sqlDB := sql.OpenDB(databaseConnector)
+	defer sqlDB.Close()
+
+	journalMode, description, err := describeSqlite(ctx, sqlDB, sqliteFilename)
+	if err != nil {
+		return append(result, newError(database.Variable, "", "SQLite: could not read database settings.", err))
+	}
+
+	result = append(result, newInfo(database.Variable, description))
+
+	if journalErr == nil && time.Since(journalInfo.ModTime()) > staleJournalAge {
+		result = append(result, newWarning(database.Variable, "", fmt.Sprintf(
+			"SQLite: stale rollback journal %s-journal, last modified %s. "+
+				"A process may have stopped mid-transaction; SQLite rolls it back on the next write.",
+			sqliteFilename,
+			journalInfo.ModTime().Format(time.RFC3339),
+		), nil))
+	}
+
+	if walErr == nil && journalMode != "wal" {
+		result = append(result, newWarning(database.Variable, "", fmt.Sprintf(
+			"SQLite: stale write-ahead log %s-wal, but the database is not in WAL mode.",
+			sqliteFilename,
+		), nil))
+	}
+
+	result = append(result, checkSqliteLock(ctx, sqlDB, database.Variable)...)
+
+	return append(result, checkSqliteIntegrity(ctx, sqlDB, database.Variable)...)
+}
+
+// checkSqliteIntegrity runs PRAGMA quick_check and, if it passes, PRAGMA integrity_check.
+func checkSqliteIntegrity(ctx context.Context, sqlDB *sql.DB, variableName string) []Finding {
+	var result []Finding
+
+	for _, pragma := range []string{"quick_check", "integrity_check"} {
+		messages, err := querySqliteCheck(ctx, sqlDB, pragma)
+		if err != nil {
+			return append(result, newError(variableName, "", "SQLite: could not run PRAGMA "+pragma+".", err))
+		}
+
+		if len(messages) != 1 || messages[0] != "ok" {
+			return append(result, newError(
+				variableName,
+				"",
+				fmt.Sprintf("SQLite: PRAGMA %s found problems: %s", pragma, strings.Join(messages, "; ")),
+				nil,
+			))
+		}
+
+		result = append(result, newInfo(variableName, "SQLite: PRAGMA "+pragma+" passed."))
+	}
+
+	return result
+}
+
+// checkSqliteLocation warns when the database is in a temporary directory or on a network filesystem.
+func checkSqliteLocation(variableName string, sqliteFilename string) []Finding {
+	var result []Finding
+
+	path, err := filepath.Abs(sqliteFilename)
+	if err != nil {
+		path = sqliteFilename
+	}
+
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	for _, temporaryDirectory := range []string{os.TempDir(), "/tmp"} {
+		if strings.HasPrefix(path, filepath.Clean(temporaryDirectory)+string(filepath.Separator)) {
+			result = append(result, newWarning(variableName, "", fmt.Sprintf(
+				"SQLite: %s is in the temporary directory %s, which may be cleared on restart.",
+				path,
+				temporaryDirectory,
+			), nil))
+
+			break
+		}
+	}
+
+	if filesystem, isNetwork := networkFilesystem(filepath.Dir(path)); isNetwork {
+		result = append(result, newWarning(variableName, "", fmt.Sprintf(
+			"SQLite: %s is on a network filesystem (%s). "+
+				"SQLite file locking is unreliable there and can corrupt the database.",
+			path,
+			filesystem,
+		), nil))
+	}
+
+	return result
+}
+
+// checkSqliteLock reports whether another process holds a lock that blocks writing.
+func checkSqliteLock(ctx context.Context, sqlDB *sql.DB, variableName string) []Finding {
+	var result []Finding
+
+	connection, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return append(result, newError(variableName, "", "SQLite: could not open a connection.", err))
+	}
+
+	defer connection.Close()
+
+	_, err = connection.ExecContext(ctx, "PRAGMA busy_timeout = 0")
+	if err != nil {
+		return append(result, newError(variableName, "", "SQLite: could not set busy_timeout.", err))
+	}
+
+	_, err = connection.ExecContext(ctx, "BEGIN IMMEDIATE")
+	if err != nil {
+		return append(result, newWarning(
+			variableName,
+			"",
+			"SQLite: could not take a write lock; another process may be holding the database.",
+			err,
+		))
+	}
+
+	_, _ = connection.ExecContext(ctx, "ROLLBACK")
+
+	return result
+}
+
+/*
+The checkSqliteWritable function verifies the SQLite file, and the directory
+that holds its journal, are writable by the running UID.
+*/
+func checkSqliteWritable(variableName string, sqliteFilename string) []Finding {
+	var result []Finding
+
+	file, err := os.OpenFile(sqliteFilename, os.O_RDWR, 0)
+	if err != nil {
+		return append(result, newError(
+			variableName,
+			"",
+			"SQLite: "+sqliteFilename+" is not writable by "+describeOwnership(sqliteFilename)+".",
+			err,
+		))
+	}
+
+	_ = file.Close()
+
+	directory := filepath.Dir(sqliteFilename)
+
+	probe, err := os.CreateTemp(directory, ".check-self-*")
+	if err != nil {
+		return append(result, newError(
+			variableName,
+			"",
+			"SQLite: directory "+directory+" is not writable by "+describeOwnership(directory)+
+				"; SQLite cannot create its journal there.",
+			err,
+		))
+	}
+
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+
+	return result
+}
+
+// describeOwnership describes the running UID and the owner and mode of path.
+// For example: "UID 1001 (owned by UID 0, mode -rw-r--r--)".
+func describeOwnership(path string) string {
+	result := fmt.Sprintf("UID %d", os.Getuid())
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return result
+	}
+
+	if owner, isOK := fileOwner(info); isOK {
+		return fmt.Sprintf("%s (owned by UID %d, mode %s)", result, owner, info.Mode())
+	}
+
+	return fmt.Sprintf("%s (mode %s)", result, info.Mode())
+}
+
+// describeSqlite returns the journal mode of a SQLite database and a description of its settings and size.
+func describeSqlite(ctx context.Context, sqlDB *sql.DB, sqliteFilename string) (string, string, error) {
+	var (
+		journalMode string
+		pageCount   int64
+		pageSize    int64
+	)
+
+	err := sqlDB.QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&journalMode)
+	if err != nil {
+		return "", "", wraperror.Errorf(err, "journal_mode")
+	}
+
+	err = sqlDB.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize)
+	if err != nil {
+		return "", "", wraperror.Errorf(err, "page_size")
+	}
+
+	err = sqlDB.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pageCount)
+	if err != nil {
+		return "", "", wraperror.Errorf(err, "page_count")
+	}
+
+	journalMode = strings.ToLower(journalMode)
+	fileSize := pageSize * pageCount
+
+	if info, err := os.Stat(sqliteFilename); err == nil {
+		fileSize = info.Size()
+	}
+
+	return journalMode, fmt.Sprintf(
+		"SQLite: journal_mode=%s, page_size=%d, %d pages, file size %d bytes.",
+		journalMode,
+		pageSize,
+		pageCount,
+		fileSize,
+	), nil
+}
+
+// querySqliteCheck runs PRAGMA quick_check or integrity_check, returning up to maxIntegrityMessages lines.
+func querySqliteCheck(ctx context.Context, sqlDB *sql.DB, pragma string) ([]string, error) {
+	var result []string
+
+	rows, err := sqlDB.QueryContext(ctx, fmt.Sprintf("PRAGMA %s(%d)", pragma, maxIntegrityMessages))
+	if err != nil {
+		return result, wraperror.Errorf(err, "%s", pragma)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var message string
+
+		err = rows.Scan(&message)
+		if err != nil {
+			return result, wraperror.Errorf(err, "scan %s", pragma)
+		}
+
+		result = append(result, message)
+	}
+
+	return result, wraperror.Errorf(rows.Err(), wraperror.NoMessage)
+}
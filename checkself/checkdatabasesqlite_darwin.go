@@ -0,0 +1,44 @@
+//go:build darwin
+
+package checkself
+
+import (
+	"os"
+	"slices"
+	"syscall"
+)
+
+// networkFilesystems are the statfs(2) type names of network filesystems.
+var networkFilesystems = []string{"afpfs", "nfs", "smbfs", "webdav"}
+
+// fileOwner returns the UID owning a file, if the platform reports it.
+func fileOwner(info os.FileInfo) (int, bool) {
+	stat, isOK := info.Sys().(*syscall.Stat_t)
+	if !isOK {
+		return 0, false
+	}
+
+	return int(stat.Uid), true
+}
+
+// networkFilesystem returns the name of the network filesystem holding path, if it is on one.
+func networkFilesystem(path string) (string, bool) {
+	var stat syscall.Statfs_t
+
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return "", false
+	}
+
+	typeName := make([]byte, 0, len(stat.Fstypename))
+
+	for _, character := range stat.Fstypename {
+		if character == 0 {
+			break
+		}
+
+		typeName = append(typeName, byte(character))
+	}
+
+	return string(typeName), slices.Contains(networkFilesystems, string(typeName))
+}
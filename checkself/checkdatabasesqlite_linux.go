@@ -0,0 +1,126 @@
+//go:build linux
+
+package checkself
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+const (
+	fuseMagic     = 0x65735546
+	mountInfoFile = "/proc/self/mountinfo"
+)
+
+// networkFilesystems maps the statfs(2) magic numbers of network filesystems to their names.
+var networkFilesystems = map[uint32]string{
+	0x00c36400: "ceph",
+	0x01021997: "9p",
+	0x517b:     "smb",
+	0x564c:     "ncp",
+	0x5346414f: "afs",
+	0x6969:     "nfs",
+	0xfe534d42: "smb2",
+	0xff534d42: "cifs",
+}
+
+// networkFuseFilesystems are the mountinfo types of FUSE filesystems that keep their files on the network.
+var networkFuseFilesystems = []string{
+	"fuse.blobfuse",
+	"fuse.blobfuse2",
+	"fuse.ceph-fuse",
+	"fuse.gcsfuse",
+	"fuse.glusterfs",
+	"fuse.goofys",
+	"fuse.juicefs",
+	"fuse.mountpoint-s3",
+	"fuse.rclone",
+	"fuse.s3fs",
+	"fuse.sshfs",
+}
+
+// mountPointUnescaper undoes the octal escapes of /proc/self/mountinfo.
+var mountPointUnescaper = strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+
+// fileOwner returns the UID owning a file, if the platform reports it.
+func fileOwner(info os.FileInfo) (int, bool) {
+	stat, isOK := info.Sys().(*syscall.Stat_t)
+	if !isOK {
+		return 0, false
+	}
+
+	return int(stat.Uid), true
+}
+
+/*
+The fuseFilesystem function returns the type, with its subtype (e.g.
+fuse.sshfs), of the FUSE mount holding path: the last of the longest mount
+points in /proc/self/mountinfo that contain path.
+*/
+func fuseFilesystem(path string) string {
+	result := "fuse"
+
+	contents, err := os.ReadFile(mountInfoFile)
+	if err != nil {
+		return result
+	}
+
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return result
+	}
+
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		path = resolvedPath
+	}
+
+	mountPoint := ""
+
+	for line := range strings.Lines(string(contents)) {
+		mountFields, typeFields, isOK := strings.Cut(line, " - ")
+		fields := strings.Fields(mountFields)
+		types := strings.Fields(typeFields)
+
+		if !isOK || len(fields) < 5 || len(types) == 0 {
+			continue
+		}
+
+		candidate := mountPointUnescaper.Replace(fields[4])
+		isWithin := candidate == "/" || path == candidate || strings.HasPrefix(path, candidate+"/")
+
+		if isWithin && len(candidate) >= len(mountPoint) {
+			mountPoint, result = candidate, types[0]
+		}
+	}
+
+	return result
+}
+
+/*
+The networkFilesystem function returns the name of the network filesystem
+holding path, if it is on one.  FUSE filesystems are network filesystems only
+if their subtype is in networkFuseFilesystems.
+*/
+func networkFilesystem(path string) (string, bool) {
+	var stat syscall.Statfs_t
+
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return "", false
+	}
+
+	magic := uint32(stat.Type) // #nosec G115 -- f_type is a 32-bit magic number, signed on some architectures.
+	if magic == fuseMagic {
+		fuseType := fuseFilesystem(path)
+
+		return fuseType, slices.Contains(networkFuseFilesystems, fuseType)
+	}
+
+	result, isOK := networkFilesystems[magic]
+
+	return result, isOK
+}
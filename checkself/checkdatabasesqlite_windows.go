@@ -0,0 +1,25 @@
+//go:build windows
+
+package checkself
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fileOwner returns the UID owning a file, if the platform reports it.  Windows does not.
+func fileOwner(info os.FileInfo) (int, bool) {
+	_ = info
+
+	return 0, false
+}
+
+// networkFilesystem returns "smb" if path is a UNC path (e.g. \\server\share\G2C.db).
+func networkFilesystem(path string) (string, bool) {
+	if strings.HasPrefix(filepath.Clean(path), `\\`) {
+		return "smb", true
+	}
+
+	return "", false
+}
@@ -0,0 +1,417 @@
+package checkself
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// tlsSettings is how a database or gRPC URL asks for TLS.
+type tlsSettings struct {
+	caFile     string // PEM file of trusted CAs.  Default: system roots.
+	enabled    bool   // Whether TLS is attempted at all.
+	option     string // The URL option, for messages (e.g. "sslmode=require").
+	required   bool   // Whether the connection fails if the server does not support TLS.
+	verify     bool   // Whether the certificate chain must be trusted.
+	verifyHost bool   // Whether the certificate must name the host.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CheckIDDatabaseTLS identifies the CheckDatabaseTLS check.
+const CheckIDDatabaseTLS = "database-tls"
+
+const (
+	PolicyDevelopment = "development" // Default.  Unencrypted connections are reported as information.
+	PolicyProduction  = "production"  // Unencrypted database and gRPC connections are errors.
+)
+
+const (
+	defaultCertificateDaysLeft = 30
+	mysqlCharsetUTF8MB4        = 45
+	mysqlClientProtocol41      = 0x0200
+	mysqlClientSSL             = 0x0800
+	mysqlClientSecureConn      = 0x8000
+	mysqlErrorPacket           = 0xff
+	mysqlGreetingFixedLength   = 4 + 8 + 1 // Connection id, auth-plugin-data-part-1, filler.
+	mysqlHeaderLength          = 4
+	mysqlMaxPacketSize         = 1 << 24
+	mysqlSSLRequestLength      = 32
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckDatabaseTLS method reports whether each network database connection
+is encrypted, whether the server certificate chain is trusted by the configured
+CA (sslrootcert) or the system roots, and whether certificates expire within
+CertificateDaysLeft days.  Under the production Policy, unencrypted connections are errors.
+*/
+func (checkself *BasicCheckSelf) CheckDatabaseTLS(ctx context.Context, report *Report) error {
+	for _, database := range checkself.getDatabases() {
+		parsedURL, err := ParseDatabaseURL(database.URL)
+		if err != nil || parsedURL.Scheme == "sqlite3" { // Reported by CheckDatabaseURL, or not networked.
+			continue
+		}
+
+		report.AddCheck("Check database TLS for %s: %s", database.Name, database.URL)
+		report.addDatabaseFindings(
+			CheckIDDatabaseTLS,
+			database,
+			checkself.checkDatabaseTLS(ctx, database.Variable, parsedURL)...,
+		)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (checkself *BasicCheckSelf) checkDatabaseTLS(
+	ctx context.Context,
+	variableName string,
+	parsedURL *ParsedDatabaseURL,
+) []Finding {
+	var (
+		result   []Finding
+		settings tlsSettings
+	)
+
+	switch parsedURL.Scheme {
+	case "postgresql":
+		settings = postgresTLSSettings(parsedURL)
+	case "mysql":
+		settings = mysqlTLSSettings(parsedURL)
+	default:
+		return append(result, newInfo(variableName, "TLS: not checked for "+parsedURL.Scheme+":// URLs."))
+	}
+
+	if !settings.enabled {
+		return append(result, checkself.unencrypted(variableName, settings))
+	}
+
+	state, isSupported, err := negotiateTLS(ctx, parsedURL)
+
+	switch {
+	case err != nil:
+		return append(result, newError(variableName, "", "TLS: could not negotiate TLS with the server.", err))
+	case !isSupported && settings.required:
+		return append(result, newError(
+			variableName,
+			"",
+			"TLS: server does not support TLS, but "+settings.option+".",
+			nil,
+		))
+	case !isSupported:
+		return append(result, checkself.unencrypted(variableName, settings))
+	}
+
+	result = append(result, newInfo(
+		variableName,
+		"TLS: connection is encrypted using "+tls.VersionName(state.Version)+".",
+	))
+	result = append(result, verifyCertificates(variableName, state, settings, parsedURL.Host)...)
+
+	return append(
+		result,
+		checkCertificateExpiry(variableName, state.PeerCertificates, checkself.getCertificateDaysLeft())...,
+	)
+}
+
+func (checkself *BasicCheckSelf) getCertificateDaysLeft() int {
+	if checkself.CertificateDaysLeft <= 0 {
+		return defaultCertificateDaysLeft
+	}
+
+	return checkself.CertificateDaysLeft
+}
+
+// unencrypted reports a connection that is not encrypted: information, or an error under the production Policy.
+func (checkself *BasicCheckSelf) unencrypted(variableName string, settings tlsSettings) Finding {
+	message := "TLS: connection is not encrypted (" + settings.option + ")."
+
+	if checkself.Policy == PolicyProduction {
+		return newError(variableName, "", message+" The production policy requires TLS.", nil)
+	}
+
+	return newInfo(variableName, message)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// checkCertificateExpiry reports certificates that have expired or expire within daysLeft days.
+func checkCertificateExpiry(variableName string, certificates []*x509.Certificate, daysLeft int) []Finding {
+	var result []Finding
+
+	for _, certificate := range certificates {
+		days := int(time.Until(certificate.NotAfter).Hours() / hoursPerDay)
+		expiry := certificate.NotAfter.Format(time.DateOnly)
+
+		switch {
+		case time.Now().After(certificate.NotAfter):
+			result = append(result, newError(variableName, "", fmt.Sprintf(
+				"TLS: certificate '%s' expired on %s.",
+				certificate.Subject.CommonName,
+				expiry,
+			), nil))
+		case days < daysLeft:
+			result = append(result, newWarning(variableName, "", fmt.Sprintf(
+				"TLS: certificate '%s' expires in %d days (%s).",
+				certificate.Subject.CommonName,
+				days,
+				expiry,
+			), nil))
+		}
+	}
+
+	return result
+}
+
+// mysqlTLSSettings interprets the go-sql-driver "tls" option.
+func mysqlTLSSettings(parsedURL *ParsedDatabaseURL) tlsSettings {
+	mode := parsedURL.Options["tls"]
+	result := tlsSettings{
+		caFile:     "",
+		enabled:    true,
+		option:     "tls=" + mode,
+		required:   true,
+		verify:     true,
+		verifyHost: true,
+	}
+
+	switch mode {
+	case "", "false":
+		result.enabled = false
+		result.option = "tls=false"
+	case "preferred":
+		result.required = false
+		result.verify = false
+		result.verifyHost = false
+	case "skip-verify":
+		result.verify = false
+		result.verifyHost = false
+	}
+
+	return result
+}
+
+/*
+The negotiateTLS function connects to the database, asks it to switch to TLS,
+and performs the handshake without verifying the certificate, so the
+certificate can be examined.  isSupported is false if the server declines TLS.
+*/
+func negotiateTLS(ctx context.Context, parsedURL *ParsedDatabaseURL) (*tls.ConnectionState, bool, error) {
+	var dialer net.Dialer
+
+	port := parsedURL.Port
+	if len(port) == 0 {
+		port = defaultPorts[parsedURL.Scheme]
+	}
+
+	dialer.Timeout = defaultDialTimeout
+
+	connection, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(parsedURL.Host, port))
+	if err != nil {
+		return nil, false, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	defer connection.Close()
+
+	_ = connection.SetDeadline(time.Now().Add(defaultDialTimeout))
+
+	isSupported, err := startTLS(connection, parsedURL.Scheme)
+	if err != nil || !isSupported {
+		return nil, false, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	tlsConnection := tls.Client(connection, &tls.Config{
+		InsecureSkipVerify: true, // #nosec G402 -- The certificate is verified by verifyCertificates.
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         parsedURL.Host,
+	})
+
+	err = tlsConnection.Handshake()
+	if err != nil {
+		return nil, true, wraperror.Errorf(err, "TLS handshake")
+	}
+
+	state := tlsConnection.ConnectionState()
+
+	return &state, true, nil
+}
+
+// postgresTLSSettings interprets the lib/pq "sslmode" and "sslrootcert" options.
+func postgresTLSSettings(parsedURL *ParsedDatabaseURL) tlsSettings {
+	mode := parsedURL.Options["sslmode"]
+	if len(mode) == 0 {
+		mode = "require" // The lib/pq default.
+	}
+
+	result := tlsSettings{
+		caFile:     parsedURL.Options["sslrootcert"],
+		enabled:    true,
+		option:     "sslmode=" + mode,
+		required:   true,
+		verify:     true,
+		verifyHost: false,
+	}
+
+	switch mode {
+	case "disable":
+		result.enabled = false
+	case "allow", "prefer":
+		result.required = false
+		result.verify = false
+	case "require":
+		result.verify = len(result.caFile) > 0 // As in lib/pq, a root certificate makes "require" act as "verify-ca".
+	case "verify-full":
+		result.verifyHost = true
+	}
+
+	return result
+}
+
+// readMySQLGreeting reads the server's initial handshake packet and reports whether it supports TLS.
+func readMySQLGreeting(connection net.Conn) (bool, error) {
+	header := make([]byte, mysqlHeaderLength)
+
+	_, err := io.ReadFull(connection, header)
+	if err != nil {
+		return false, wraperror.Errorf(err, "read MySQL greeting")
+	}
+
+	payload := make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+
+	_, err = io.ReadFull(connection, payload)
+	if err != nil {
+		return false, wraperror.Errorf(err, "read MySQL greeting")
+	}
+
+	if len(payload) == 0 || payload[0] == mysqlErrorPacket {
+		return false, wraperror.Errorf(errForPackage, "MySQL server refused the connection: %s", string(payload))
+	}
+
+	// Skip protocol version and NUL-terminated server version.
+
+	index := 1
+	for index < len(payload) && payload[index] != 0 {
+		index++
+	}
+
+	index += 1 + mysqlGreetingFixedLength
+	if index+2 > len(payload) {
+		return false, wraperror.Errorf(errForPackage, "MySQL greeting is too short")
+	}
+
+	capabilities := binary.LittleEndian.Uint16(payload[index : index+2])
+
+	return capabilities&mysqlClientSSL != 0, nil
+}
+
+// startTLS performs the dialect-specific request to switch an open connection to TLS.
+func startTLS(connection net.Conn, scheme string) (bool, error) {
+	if scheme == "postgresql" {
+		answer, err := requestPostgresTLS(connection)
+
+		return answer == postgresSSLAccepted, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	isSupported, err := readMySQLGreeting(connection)
+	if err != nil || !isSupported {
+		return false, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	request := make([]byte, mysqlHeaderLength+mysqlSSLRequestLength)
+	request[0] = mysqlSSLRequestLength
+	request[3] = 1 // Sequence number.
+	binary.LittleEndian.PutUint32(request[4:8], mysqlClientProtocol41|mysqlClientSSL|mysqlClientSecureConn)
+	binary.LittleEndian.PutUint32(request[8:12], mysqlMaxPacketSize)
+	request[12] = mysqlCharsetUTF8MB4
+
+	_, err = connection.Write(request)
+
+	return true, wraperror.Errorf(err, "write MySQL SSL request")
+}
+
+/*
+The verifyCertificates function checks the server's certificate chain against
+the CA file, or the system roots if none is configured.  An untrusted chain is
+an error if the URL asks for verification, otherwise a warning.
+*/
+func verifyCertificates(
+	variableName string,
+	state *tls.ConnectionState,
+	settings tlsSettings,
+	host string,
+) []Finding {
+	var (
+		options x509.VerifyOptions
+		result  []Finding
+	)
+
+	if len(state.PeerCertificates) == 0 { // Short-circuit exit.
+		return append(result, newError(variableName, "", "TLS: server sent no certificate.", nil))
+	}
+
+	if len(settings.caFile) > 0 {
+		caPEM, err := os.ReadFile(settings.caFile)
+		if err != nil {
+			return append(result, newError(variableName, "", "TLS: could not read CA file "+settings.caFile+".", err))
+		}
+
+		options.Roots = x509.NewCertPool()
+		if !options.Roots.AppendCertsFromPEM(caPEM) {
+			return append(result, newError(
+				variableName,
+				"",
+				"TLS: no certificates found in CA file "+settings.caFile+".",
+				nil,
+			))
+		}
+	}
+
+	options.Intermediates = x509.NewCertPool()
+	for _, certificate := range state.PeerCertificates[1:] {
+		options.Intermediates.AddCert(certificate)
+	}
+
+	if settings.verifyHost {
+		options.DNSName = host
+	}
+
+	_, err := state.PeerCertificates[0].Verify(options)
+
+	switch {
+	case err == nil:
+		return result
+	case settings.verify:
+		return append(result, newError(variableName, "", "TLS: server certificate is not trusted.", err))
+	default:
+		return append(result, newWarning(
+			variableName,
+			"",
+			"TLS: server certificate is not trusted; "+settings.option+" does not verify it.",
+			err,
+		))
+	}
+}
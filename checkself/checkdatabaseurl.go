@@ -3,130 +3,124 @@ package checkself
 import (
 	"context"
 	"database/sql"
-	"fmt"
-	"net/url"
 	"os"
-	"slices"
-	"strings"
 
 	"github.com/senzing-garage/go-cmdhelping/option"
-	"github.com/senzing-garage/go-databasing/connector"
 	"github.com/senzing-garage/go-databasing/dbhelper"
 )
 
+// CheckIDDatabaseURL identifies the CheckDatabaseURL check.
+const CheckIDDatabaseURL = "database-url"
+
 // ----------------------------------------------------------------------------
 // Interface methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) CheckDatabaseURL(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
+func (checkself *BasicCheckSelf) CheckDatabaseURL(ctx context.Context, report *Report) error {
 	var err error
 
+	databases := checkself.getDatabases()
+
 	// Short-circuit exit.
 
-	if len(checkself.DatabaseURL) == 0 {
-		return reportChecks, reportInfo, reportErrors, err
+	if len(databases) == 0 {
+		return checkself.checkConnectionIsSet(report)
 	}
 
-	// Prolog.
+	// Check each database URL.
 
-	reportChecks = append(
-		reportChecks,
-		fmt.Sprintf("Check database URL: %s = %s", option.DatabaseURL.Envar, checkself.DatabaseURL),
-	)
+	for _, database := range databases {
+		report.AddCheck("Check database URL for %s: %s = %s", database.Name, database.Variable, database.URL)
+		report.addDatabaseFindings(
+			CheckIDDatabaseURL,
+			database,
+			checkDatabaseURL(ctx, database.Variable, database.URL, checkself.Policy)...,
+		)
+	}
 
-	// Check database URL.
+	// Epilog.
 
-	reportErrors = append(reportErrors, CheckDatabaseURL(ctx, option.DatabaseURL.Envar, checkself.DatabaseURL)...)
+	return err
+}
 
-	// Epilog.
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// checkConnectionIsSet reports settings that parse, but name no database.
+func (checkself *BasicCheckSelf) checkConnectionIsSet(report *Report) error {
+	if len(checkself.Settings) == 0 {
+		return nil
+	}
+
+	_, err := ParseDatabases(checkself.Settings)
+	if err != nil { // Reported by CheckSettings.
+		return nil
+	}
+
+	connectionVariable := option.CoreSettings.Envar + ".SQL.CONNECTION"
 
-	return reportChecks, reportInfo, reportErrors, err
+	report.AddCheck("Check database URL for %s: %s", CoreDatabaseName, connectionVariable)
+	report.addFindings(CheckIDDatabaseURL, newError(connectionVariable, "", connectionVariable+" is not set.", nil))
+
+	return nil
 }
 
 // ----------------------------------------------------------------------------
 // Private functions
 // ----------------------------------------------------------------------------
 
-func CheckDatabaseURL(ctx context.Context, variableName string, databaseURL string) []string {
-	result := []string{}
-
-	// Parse the database URL.
+// CheckDatabaseURL checks a database URL under the development Policy.
+func CheckDatabaseURL(ctx context.Context, variableName string, databaseURL string) []Finding {
+	return checkDatabaseURL(ctx, variableName, databaseURL, PolicyDevelopment)
+}
 
-	normalizedDatabaseURL := databaseURL
+func checkDatabaseURL(ctx context.Context, variableName string, databaseURL string, policy string) []Finding {
+	result := []Finding{}
 
-	if strings.HasPrefix(databaseURL, "postgresql") {
-		index := strings.LastIndex(databaseURL, ":")
-		normalizedDatabaseURL = databaseURL[:index] + "/" + databaseURL[index+1:]
-	}
+	// Parse the database URL.
 
-	parsedURL, err := url.Parse(normalizedDatabaseURL)
+	parsedURL, err := ParseDatabaseURL(databaseURL)
 	if err != nil {
-		return append(result, fmt.Sprintf(
-			"%s = %s is misconfigured. Could not parse database URL. For more information, visit https://hub.senzing.com/...  Error: %s",
-			variableName,
-			databaseURL,
-			err.Error(),
-		))
+		return append(result, newError(variableName, databaseURL, err.Error(), nil))
 	}
 
-	// Check database URL scheme.
+	// Specific database URL scheme checks.
 
-	if len(parsedURL.Scheme) == 0 {
-		return append(result, fmt.Sprintf(
-			"%s = %s is misconfigured. A database scheme is needed (e.g. postgresql://...). For more information, visit https://hub.senzing.com/...",
-			variableName,
-			databaseURL,
-		))
+	result = append(result, checkDialect(variableName, databaseURL, parsedURL, policy)...)
+	if hasErrors(result) { // Short-circuit exit.
+		return result
 	}
 
-	databaseSchemes := []string{
-		"sqlite3",
-		"postgresql",
-		"mysql",
-		"mssql",
-	}
+	// Check network connectivity, one stage at a time.
 
-	if !slices.Contains(databaseSchemes, parsedURL.Scheme) {
-		return append(result, fmt.Sprintf(
-			"%s = %s is misconfigured. Scheme '%s://' is not recognized. For more information, visit https://hub.senzing.com/...",
-			variableName,
-			databaseURL,
-			parsedURL.Scheme,
-		))
+	if parsedURL.Scheme != "sqlite3" {
+		result = append(result, probeNetwork(ctx, variableName, parsedURL)...)
+		if hasErrors(result) { // Short-circuit exit.
+			return result
+		}
 	}
 
-	// Specific database URL scheme checks.
+	// Check database connection.
 
-	if parsedURL.Scheme == "sqlite3" {
-		result = append(result, checkSqlite(variableName, databaseURL)...)
+	if parsedURL.Scheme == "db2" {
+		return append(result, newWarning(
+			variableName,
+			"",
+			"Could not test the DB2 connection: no DB2 driver is available.",
+			nil,
+		))
 	}
 
-	result = append(result, checkDatabaseConnection(ctx, variableName, databaseURL)...)
-
-	// Check database connector creation.
-
-	return result
+	return append(result, checkDatabaseConnection(ctx, variableName, databaseURL, parsedURL.User)...)
 }
 
-func checkDatabaseConnection(ctx context.Context, variableName string, databaseURL string) []string {
-	var result []string
+func checkDatabaseConnection(ctx context.Context, variableName string, databaseURL string, user string) []Finding {
+	var result []Finding
 
-	databaseConnector, err := connector.NewConnector(ctx, databaseURL)
+	databaseConnector, err := newDatabaseConnector(ctx, databaseURL)
 	if err != nil {
-		return append(
-			result,
-			fmt.Sprintf(
-				"%s = %s is misconfigured. Could not make a new connector. For more information, visit https://hub.senzing.com/...  Error: %s",
-				variableName,
-				databaseURL,
-				err.Error(),
-			),
-		)
+		return append(result, newError(variableName, databaseURL, "Could not make a new connector.", err))
 	}
 
 	// Check database connection.
@@ -135,41 +129,70 @@ func checkDatabaseConnection(ctx context.Context, variableName string, databaseU
 	defer database.Close()
 
 	err = database.PingContext(ctx)
+	if err != nil && isAuthenticationFailure(err) {
+		return append(result, newCritical(
+			variableName,
+			databaseURL,
+			"Authentication: login failed for user '"+user+"'.",
+			err,
+		))
+	}
+
 	if err != nil {
-		return append(
-			result,
-			fmt.Sprintf(
-				"%s = %s is misconfigured. Could not connect. For more information, visit https://hub.senzing.com/...  Error: %s",
-				variableName,
-				databaseURL,
-				err.Error(),
-			),
-		)
+		return append(result, newCritical(variableName, databaseURL, "Could not connect.", err))
 	}
 
 	return result
 }
 
-func checkSqlite(variableName string, databaseURL string) []string {
-	var result []string
+// checkDialect performs the checks specific to the scheme of a database URL.
+func checkDialect(variableName string, databaseURL string, parsedURL *ParsedDatabaseURL, policy string) []Finding {
+	var result []Finding
+
+	switch parsedURL.Scheme {
+	case "sqlite3":
+		result = checkSqlite(variableName, databaseURL, policy)
+	case "mssql", "mysql", "postgresql":
+		result = checkNetworkDatabase(variableName, databaseURL, parsedURL)
+	case "oci", "oracle":
+		result = checkOracle(variableName, databaseURL, parsedURL)
+	case "db2":
+		result = checkDB2(variableName, databaseURL, parsedURL)
+	}
+
+	return result
+}
+
+// checkSqlite notes the use of SQLite: information, or a warning under the production Policy.
+func checkSqlite(variableName string, databaseURL string, policy string) []Finding {
+	var result []Finding
 
 	sqliteFilename, err := dbhelper.ExtractSqliteDatabaseFilename(databaseURL)
 	if err != nil {
-		return append(result, fmt.Sprintf(
-			"%s = %s is misconfigured. Error: %s. For more information, visit https://hub.senzing.com/...",
-			variableName,
-			databaseURL,
-			err.Error()))
+		return append(result, newError(variableName, databaseURL, "Could not extract SQLite filename.", err))
 	}
 
 	_, err = os.Stat(sqliteFilename)
 	if err != nil {
-		return append(result, fmt.Sprintf(
-			"%s = %s is misconfigured. Could not find %s. For more information, visit https://hub.senzing.com/...",
-			variableName,
-			databaseURL,
-			sqliteFilename))
+		return append(result, newError(variableName, databaseURL, "Could not find "+sqliteFilename+".", nil))
 	}
 
-	return result
+	message := "SQLite is intended for evaluation only. Use PostgreSQL, MySQL, MSSQL, or Oracle in production."
+
+	if policy == PolicyProduction {
+		return append(result, newWarning(variableName, "", message, nil))
+	}
+
+	return append(result, newInfo(variableName, message))
+}
+
+// hasErrors reports whether any finding has a severity of SeverityError or higher.
+func hasErrors(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity >= SeverityError {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,233 @@
+package checkself
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// buildVersion is the content of szBuildVersion.json in a Senzing installation, or of SzProduct.GetVersion.
+type buildVersion struct {
+	BuildVersion string `json:"BUILD_VERSION"`
+	Version      string `json:"VERSION"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CheckIDEngine identifies the CheckEngine check.
+const CheckIDEngine = "engine"
+
+// ExpectedSenzingMajorVersion is the major version of Senzing the engine checks are built for.
+const ExpectedSenzingMajorVersion = "4"
+
+const buildVersionFilename = "szBuildVersion.json"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// engineMutex serializes engine checks; Senzing allows one SzAbstractFactory at a time.
+var engineMutex sync.Mutex
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckEngine method probes for the Senzing native library before the checks
+that call the engine.  The library must be present, built for the running
+architecture, loadable with its dependencies, and from the expected Senzing
+major version.  The library is loaded (e.g. with dlopen) and unloaded again.
+In a build linked with the library, the dynamic linker has already loaded a
+copy at startup; the check then confirms that the copy found below loads too.
+
+If SzAbstractFactoryCreator or GrpcURL is set, the engine checks use it and the
+library is not probed.  Otherwise, if SenzingDirectory is set, the library is
+expected in its lib directory.  Otherwise, it is searched for on the library
+path (e.g. LD_LIBRARY_PATH), then in the directories of the system's dynamic
+linker (e.g. /etc/ld.so.conf, /usr/lib), and then in the default Senzing directory.
+*/
+func (checkself *BasicCheckSelf) CheckEngine(ctx context.Context, report *Report) error {
+	_ = ctx
+
+	if checkself.SzAbstractFactoryCreator != nil {
+		report.AddCheck("Check Senzing native library: not used; SzAbstractFactoryCreator is set")
+
+		return nil
+	}
+
+	if len(checkself.GrpcURL) > 0 {
+		report.AddCheck("Check Senzing native library: not used; GrpcURL is set")
+
+		return nil
+	}
+
+	searchPath := checkself.getLibrarySearchPath()
+
+	report.AddCheck(
+		"Check Senzing native library: %s in %s",
+		libraryName,
+		strings.Join(searchPath, string(os.PathListSeparator)),
+	)
+
+	libraryFile, isFound := findLibrary(searchPath)
+	if !isFound {
+		report.addFindings(CheckIDEngine, newError(
+			libraryPathVariable,
+			"",
+			fmt.Sprintf("Engine checks skipped: %s not found at %s.", libraryName, strings.Join(searchPath, ", ")),
+			nil,
+		))
+
+		return nil
+	}
+
+	err := checkLibraryFormat(libraryFile)
+	if err != nil {
+		report.addFindings(CheckIDEngine, newError(
+			libraryPathVariable,
+			"",
+			"Engine checks skipped: "+libraryFile+" is not a shared library built for this architecture.",
+			err,
+		))
+
+		return nil
+	}
+
+	err = loadLibrary(libraryFile)
+	if err != nil {
+		report.addFindings(CheckIDEngine, newError(
+			libraryPathVariable,
+			"",
+			"Engine checks skipped: "+libraryFile+" could not be loaded.",
+			err,
+		))
+
+		return nil
+	}
+
+	report.addFindings(CheckIDEngine, checkLibraryVersion(libraryFile)...)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// getLibrarySearchPath returns the directories, in order, in which the Senzing native library is looked for.
+func (checkself *BasicCheckSelf) getLibrarySearchPath() []string {
+	if len(checkself.SenzingDirectory) > 0 {
+		return []string{filepath.Join(checkself.SenzingDirectory, "lib")}
+	}
+
+	var result []string
+
+	directories := filepath.SplitList(os.Getenv(libraryPathVariable))
+	directories = append(directories, systemLibraryDirectories()...)
+
+	if len(defaultSenzingDirectory) > 0 {
+		directories = append(directories, filepath.Join(defaultSenzingDirectory, "lib"))
+	}
+
+	for _, directory := range directories {
+		if len(directory) > 0 && !slices.Contains(result, directory) {
+			result = append(result, directory)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+/*
+The checkLibraryVersion function compares the version of the Senzing
+installation holding the library, from szBuildVersion.json in the directory
+above the library's, with ExpectedSenzingMajorVersion.
+*/
+func checkLibraryVersion(libraryFile string) []Finding {
+	var result []Finding
+
+	versionFile := filepath.Join(filepath.Dir(filepath.Dir(libraryFile)), buildVersionFilename)
+
+	version, err := readBuildVersion(versionFile)
+	if err != nil {
+		return append(result, newWarning(
+			option.SenzingDirectory.Envar,
+			"",
+			"Could not read the Senzing version from "+versionFile+"; "+libraryFile+" may not be the expected version.",
+			err,
+		))
+	}
+
+	majorVersion, _, _ := strings.Cut(version.Version, ".")
+	if majorVersion != ExpectedSenzingMajorVersion {
+		return append(result, newError(option.SenzingDirectory.Envar, "", fmt.Sprintf(
+			"Engine checks skipped: %s is Senzing %s, but check-self expects Senzing %s.x.",
+			libraryFile,
+			version.Version,
+			ExpectedSenzingMajorVersion,
+		), nil))
+	}
+
+	return append(result, newInfo(
+		option.SenzingDirectory.Envar,
+		fmt.Sprintf("Engine: %s is Senzing %s (build %s).", libraryFile, version.Version, version.BuildVersion),
+	))
+}
+
+// findLibrary returns the first file named libraryName in the directories of searchPath.
+func findLibrary(searchPath []string) (string, bool) {
+	for _, directory := range searchPath {
+		candidate := filepath.Join(directory, libraryName)
+
+		info, err := os.Stat(candidate)
+		if err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+func readBuildVersion(versionFile string) (buildVersion, error) {
+	contents, err := os.ReadFile(versionFile)
+	if err != nil {
+		return buildVersion{}, wraperror.Errorf(err, "read %s", versionFile)
+	}
+
+	return parseBuildVersion(contents, versionFile)
+}
+
+// parseBuildVersion parses a Senzing version document, as in szBuildVersion.json or from SzProduct.GetVersion.
+func parseBuildVersion(contents []byte, source string) (buildVersion, error) {
+	var result buildVersion
+
+	err := json.Unmarshal(contents, &result)
+	if err != nil {
+		return result, wraperror.Errorf(err, "parse %s", source)
+	}
+
+	if len(result.Version) == 0 {
+		return result, wraperror.Errorf(errForPackage, "%s has no VERSION", source)
+	}
+
+	return result, nil
+}
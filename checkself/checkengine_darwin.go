@@ -0,0 +1,83 @@
+//go:build darwin
+
+package checkself
+
+import (
+	"debug/macho"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+const (
+	defaultSenzingDirectory = "/opt/senzing/er"
+	libraryName             = "libSz.dylib"
+	libraryPathVariable     = "DYLD_LIBRARY_PATH"
+)
+
+// machoCPUs maps GOARCH to the Mach-O CPU of libraries it can load.
+var machoCPUs = map[string]macho.Cpu{
+	"amd64": macho.CpuAmd64,
+	"arm64": macho.CpuArm64,
+}
+
+/*
+The checkLibraryFormat function returns an error unless libraryFile is a Mach-O
+dynamic library, or a universal binary holding one, for the running architecture.
+*/
+func checkLibraryFormat(libraryFile string) error {
+	var cpus []macho.Cpu
+
+	file, err := macho.Open(libraryFile)
+	if err == nil {
+		defer file.Close()
+
+		if file.Type != macho.TypeDylib {
+			return wraperror.Errorf(errForPackage, "Mach-O type %s, not a dynamic library", file.Type)
+		}
+
+		cpus = append(cpus, file.Cpu)
+	} else {
+		fatFile, fatErr := macho.OpenFat(libraryFile)
+		if fatErr != nil {
+			return wraperror.Errorf(err, "not a Mach-O file")
+		}
+
+		defer fatFile.Close()
+
+		for _, arch := range fatFile.Arches {
+			cpus = append(cpus, arch.Cpu)
+		}
+	}
+
+	expected, isOK := machoCPUs[runtime.GOARCH]
+	if isOK && !slices.Contains(cpus, expected) {
+		return wraperror.Errorf(errForPackage, "built for %v, not %s", cpus, runtime.GOARCH)
+	}
+
+	return nil
+}
+
+/*
+The systemLibraryDirectories function returns the directories dyld falls back
+to after DYLD_LIBRARY_PATH: DYLD_FALLBACK_LIBRARY_PATH or, if it is not set,
+~/lib, /usr/local/lib, and /usr/lib.
+*/
+func systemLibraryDirectories() []string {
+	fallbackPath := os.Getenv("DYLD_FALLBACK_LIBRARY_PATH")
+	if len(fallbackPath) > 0 {
+		return filepath.SplitList(fallbackPath)
+	}
+
+	result := []string{"/usr/local/lib", "/usr/lib"}
+
+	homeDirectory, err := os.UserHomeDir()
+	if err == nil {
+		result = append([]string{filepath.Join(homeDirectory, "lib")}, result...)
+	}
+
+	return result
+}
@@ -0,0 +1,102 @@
+//go:build linux
+
+package checkself
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+const (
+	defaultSenzingDirectory = "/opt/senzing/er"
+	libraryName             = "libSz.so"
+	libraryPathVariable     = "LD_LIBRARY_PATH"
+	linkerConfigurationFile = "/etc/ld.so.conf"
+)
+
+// elfMachines maps GOARCH to the ELF machine of libraries it can load.
+var elfMachines = map[string]elf.Machine{
+	"386":     elf.EM_386,
+	"amd64":   elf.EM_X86_64,
+	"arm64":   elf.EM_AARCH64,
+	"ppc64le": elf.EM_PPC64,
+	"s390x":   elf.EM_S390,
+}
+
+// trustedLibraryDirectories are searched by the dynamic linker after those in linkerConfigurationFile.
+var trustedLibraryDirectories = []string{"/lib64", "/usr/lib64", "/lib", "/usr/lib"}
+
+// checkLibraryFormat returns an error unless libraryFile is an ELF shared library for the running architecture.
+func checkLibraryFormat(libraryFile string) error {
+	file, err := elf.Open(libraryFile)
+	if err != nil {
+		return wraperror.Errorf(err, "not an ELF file")
+	}
+
+	defer file.Close()
+
+	if file.Type != elf.ET_DYN {
+		return wraperror.Errorf(errForPackage, "ELF type %s, not a shared library", file.Type)
+	}
+
+	expected, isOK := elfMachines[runtime.GOARCH]
+	if isOK && file.Machine != expected {
+		return wraperror.Errorf(errForPackage, "built for %s, not %s", file.Machine, runtime.GOARCH)
+	}
+
+	return nil
+}
+
+/*
+The systemLibraryDirectories function returns the directories the dynamic
+linker searches after LD_LIBRARY_PATH, as cached by ldconfig: those listed in
+/etc/ld.so.conf and the files it includes, then the trusted directories.
+*/
+func systemLibraryDirectories() []string {
+	return append(readLinkerConfiguration(linkerConfigurationFile, map[string]bool{}), trustedLibraryDirectories...)
+}
+
+// readLinkerConfiguration returns the directories listed in an ld.so.conf file, following include directives.
+func readLinkerConfiguration(configurationFile string, isRead map[string]bool) []string {
+	var result []string
+
+	if isRead[configurationFile] { // Short-circuit exit.
+		return result
+	}
+
+	isRead[configurationFile] = true
+
+	contents, err := os.ReadFile(configurationFile)
+	if err != nil { // No configuration; the trusted directories remain.
+		return result
+	}
+
+	for line := range strings.Lines(string(contents)) {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.FieldsFunc(line, func(r rune) bool { return strings.ContainsRune(" \t\n,:", r) })
+
+		switch {
+		case len(fields) == 0 || fields[0] == "hwcap":
+		case fields[0] == "include":
+			for _, pattern := range fields[1:] {
+				if !filepath.IsAbs(pattern) {
+					pattern = filepath.Join(filepath.Dir(configurationFile), pattern)
+				}
+
+				matches, _ := filepath.Glob(pattern)
+				for _, match := range matches {
+					result = append(result, readLinkerConfiguration(match, isRead)...)
+				}
+			}
+		default:
+			result = append(result, fields...)
+		}
+	}
+
+	return result
+}
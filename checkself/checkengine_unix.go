@@ -0,0 +1,42 @@
+//go:build linux || darwin
+
+package checkself
+
+/*
+#cgo linux LDFLAGS: -ldl
+#include <dlfcn.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+/*
+The loadLibrary function loads libraryFile with dlopen, resolving all of its
+symbols and dependencies, then unloads it.  If the process already has
+libraryFile loaded, dlopen only counts another reference to it.
+*/
+func loadLibrary(libraryFile string) error {
+	cLibraryFile := C.CString(libraryFile)
+	defer C.free(unsafe.Pointer(cLibraryFile))
+
+	// dlerror reports the last failure on the calling thread.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	handle := C.dlopen(cLibraryFile, C.RTLD_NOW|C.RTLD_LOCAL)
+	if handle == nil {
+		return wraperror.Errorf(errForPackage, "dlopen: %s", C.GoString(C.dlerror()))
+	}
+
+	if C.dlclose(handle) != 0 {
+		return wraperror.Errorf(errForPackage, "dlclose: %s", C.GoString(C.dlerror()))
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+//go:build windows
+
+package checkself
+
+import (
+	"debug/pe"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+const (
+	defaultSenzingDirectory = "" // No default; Sz.dll is found on PATH.
+	libraryName             = "Sz.dll"
+	libraryPathVariable     = "PATH"
+)
+
+// peMachines maps GOARCH to the PE machine of libraries it can load.
+var peMachines = map[string]uint16{
+	"386":   pe.IMAGE_FILE_MACHINE_I386,
+	"amd64": pe.IMAGE_FILE_MACHINE_AMD64,
+	"arm64": pe.IMAGE_FILE_MACHINE_ARM64,
+}
+
+// checkLibraryFormat returns an error unless libraryFile is a DLL for the running architecture.
+func checkLibraryFormat(libraryFile string) error {
+	file, err := pe.Open(libraryFile)
+	if err != nil {
+		return wraperror.Errorf(err, "not a PE file")
+	}
+
+	defer file.Close()
+
+	if file.Characteristics&pe.IMAGE_FILE_DLL == 0 {
+		return wraperror.Errorf(errForPackage, "not a DLL")
+	}
+
+	expected, isOK := peMachines[runtime.GOARCH]
+	if isOK && file.Machine != expected {
+		return wraperror.Errorf(errForPackage, "built for machine 0x%x, not %s", file.Machine, runtime.GOARCH)
+	}
+
+	return nil
+}
+
+// loadLibrary loads libraryFile with LoadLibrary, which also loads the DLLs it imports, then frees it.
+func loadLibrary(libraryFile string) error {
+	handle, err := syscall.LoadLibrary(libraryFile)
+	if err != nil {
+		return wraperror.Errorf(err, "LoadLibrary")
+	}
+
+	return wraperror.Errorf(syscall.FreeLibrary(handle), "FreeLibrary")
+}
+
+// systemLibraryDirectories returns the Windows system directories, which LoadLibrary also searches.
+func systemLibraryDirectories() []string {
+	var result []string
+
+	systemRoot := os.Getenv("SystemRoot")
+	if len(systemRoot) > 0 {
+		result = append(result, filepath.Join(systemRoot, "System32"), systemRoot)
+	}
+
+	return result
+}
@@ -0,0 +1,468 @@
+package checkself
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// CheckFunc is the signature of the function that performs a check.
+type CheckFunc func(ctx context.Context, report *Report) error
+
+/*
+The Checker interface is a single check run by BasicCheckSelf.
+
+A Checker adds information, descriptions of what it checked, and findings to
+the report.  A check fails if it adds a finding of SeverityError or higher, or
+if it returns an error; problems in the environment should be reported as
+findings.
+
+Dependencies lists the IDs of checks that must pass before this check runs.
+If a dependency does not pass, this check is skipped.  Dependencies must be
+registered before their dependents; dependencies that are not registered are
+ignored.  Checks without unfinished dependencies run concurrently, so a
+Checker must not modify shared state.  The ctx passed to Check is cancelled
+when the check times out.
+
+Groups lists the names (e.g. CheckGroupDatabase) by which the check can be
+selected or skipped along with related checks.
+*/
+type Checker interface {
+	Check(ctx context.Context, report *Report) error
+	Dependencies() []string
+	Description() string
+	Groups() []string
+	ID() string
+}
+
+// SimpleChecker adapts a CheckFunc to the Checker interface.
+type SimpleChecker struct {
+	CheckDependencies []string
+	CheckDescription  string
+	CheckFunc         CheckFunc
+	CheckGroups       []string
+	CheckID           string
+}
+
+// engineChecker is a check that calls the Senzing engine.  Its dependencies follow GrpcURL when they are read.
+type engineChecker struct {
+	SimpleChecker
+	checkself         *BasicCheckSelf
+	localDependencies []string // Apply unless GrpcURL is set. See engineDependencies.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Groups of built-in checks.
+const (
+	CheckGroupDatabase   = "database"   // Database URL and schema.
+	CheckGroupEngine     = "engine"     // Senzing native library or gRPC server, engine settings, and configuration.
+	CheckGroupInfo       = "info"       // Version and variable listings.
+	CheckGroupLicense    = "license"    // Senzing license.
+	CheckGroupMonitoring = "monitoring" // Observer endpoint.
+	CheckGroupPaths      = "paths"      // Configuration, resource, and support paths.
+)
+
+// ----------------------------------------------------------------------------
+// SimpleChecker methods
+// ----------------------------------------------------------------------------
+
+// Check runs the CheckFunc.
+func (checker *SimpleChecker) Check(ctx context.Context, report *Report) error {
+	return checker.CheckFunc(ctx, report)
+}
+
+// Dependencies returns the IDs of checks that must pass before this check runs.
+func (checker *SimpleChecker) Dependencies() []string {
+	return checker.CheckDependencies
+}
+
+// Description returns a human-readable summary of what the check verifies.
+func (checker *SimpleChecker) Description() string {
+	return checker.CheckDescription
+}
+
+// Groups returns the names of the groups the check belongs to.
+func (checker *SimpleChecker) Groups() []string {
+	return checker.CheckGroups
+}
+
+// ID returns the unique identifier of the check.
+func (checker *SimpleChecker) ID() string {
+	return checker.CheckID
+}
+
+// ----------------------------------------------------------------------------
+// engineChecker methods
+// ----------------------------------------------------------------------------
+
+// Dependencies returns the IDs of checks that must pass before this check runs, as of the current GrpcURL.
+func (checker *engineChecker) Dependencies() []string {
+	return checker.checkself.engineDependencies(checker.localDependencies...)
+}
+
+// ----------------------------------------------------------------------------
+// Registry methods
+// ----------------------------------------------------------------------------
+
+/*
+The Checkers method returns the registered checkers in the order they run.
+Unless modified, the built-in checks are registered.
+
+Output
+  - A copy of the registry.
+*/
+func (checkself *BasicCheckSelf) Checkers() []Checker {
+	return slices.Clone(checkself.getCheckers())
+}
+
+/*
+The RegisterChecker method adds a checker to the end of the registry.
+The registry is not safe for concurrent modification; configure it before calling Run.
+
+Input
+  - checker: The checker to add.  Its ID must not already be registered.
+*/
+func (checkself *BasicCheckSelf) RegisterChecker(checker Checker) error {
+	if len(checker.ID()) == 0 {
+		return wraperror.Errorf(errForPackage, "checker ID must not be empty")
+	}
+
+	if checkself.indexOfChecker(checker.ID()) >= 0 {
+		return wraperror.Errorf(errForPackage, "checker %s already registered", checker.ID())
+	}
+
+	checkself.checkers = append(checkself.getCheckers(), checker)
+
+	return nil
+}
+
+/*
+The ReorderCheckers method moves the identified checkers to the front of the
+registry in the order given.  Unlisted checkers keep their relative order.
+
+Input
+  - checkerIDs: Identifiers of registered checkers.
+*/
+func (checkself *BasicCheckSelf) ReorderCheckers(checkerIDs ...string) error {
+	checkers := checkself.getCheckers()
+	result := make([]Checker, 0, len(checkers))
+
+	for _, checkerID := range checkerIDs {
+		index := checkself.indexOfChecker(checkerID)
+		if index < 0 {
+			return wraperror.Errorf(errForPackage, "checker %s not registered", checkerID)
+		}
+
+		if slices.Contains(result, checkers[index]) {
+			return wraperror.Errorf(errForPackage, "checker %s listed more than once", checkerID)
+		}
+
+		result = append(result, checkers[index])
+	}
+
+	for _, checker := range checkers {
+		if !slices.Contains(result, checker) {
+			result = append(result, checker)
+		}
+	}
+
+	checkself.checkers = result
+
+	return nil
+}
+
+/*
+The UnregisterChecker method removes a checker from the registry.
+
+Input
+  - checkerID: Identifier of a registered checker.
+*/
+func (checkself *BasicCheckSelf) UnregisterChecker(checkerID string) error {
+	index := checkself.indexOfChecker(checkerID)
+	if index < 0 {
+		return wraperror.Errorf(errForPackage, "checker %s not registered", checkerID)
+	}
+
+	checkself.checkers = slices.Delete(checkself.getCheckers(), index, index+1)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+The defaultCheckers method returns the built-in checks.  Dependencies must
+precede their dependents.  The dependencies of the engine checks follow
+GrpcURL as it is when Run starts the checks, not when they are registered.
+*/
+func (checkself *BasicCheckSelf) defaultCheckers() []Checker {
+	return []Checker{
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "Report the date and version of check-self",
+			CheckFunc:         checkself.Prolog,
+			CheckGroups:       []string{CheckGroupInfo},
+			CheckID:           CheckIDProlog,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "List SENZING_TOOLS_* environment variables",
+			CheckFunc:         checkself.ListEnvironmentVariables,
+			CheckGroups:       []string{CheckGroupInfo},
+			CheckID:           CheckIDEnvironmentVariables,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "List command line variables",
+			CheckFunc:         checkself.ListStructVariables,
+			CheckGroups:       []string{CheckGroupInfo},
+			CheckID:           CheckIDStructVariables,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "Verify the configuration path contains the required files",
+			CheckFunc:         checkself.CheckConfigPath,
+			CheckGroups:       []string{CheckGroupPaths},
+			CheckID:           CheckIDConfigPath,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "Verify the resource path contains the required files",
+			CheckFunc:         checkself.CheckResourcePath,
+			CheckGroups:       []string{CheckGroupPaths},
+			CheckID:           CheckIDResourcePath,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "Verify the support path contains the required files",
+			CheckFunc:         checkself.CheckSupportPath,
+			CheckGroups:       []string{CheckGroupPaths},
+			CheckID:           CheckIDSupportPath,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "Verify the database URL is well-formed and the database is reachable",
+			CheckFunc:         checkself.CheckDatabaseURL,
+			CheckGroups:       []string{CheckGroupDatabase},
+			CheckID:           CheckIDDatabaseURL,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "Verify the Senzing engine settings",
+			CheckFunc:         checkself.CheckSettings,
+			CheckGroups:       []string{CheckGroupEngine},
+			CheckID:           CheckIDSettings,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "Verify the Senzing native library is present, loadable, and the expected version",
+			CheckFunc:         checkself.CheckEngine,
+			CheckGroups:       []string{CheckGroupEngine},
+			CheckID:           CheckIDEngine,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "Verify gRPC connections are encrypted with trusted, unexpired certificates",
+			CheckFunc:         checkself.CheckGrpcTLS,
+			CheckGroups:       []string{CheckGroupEngine, CheckGroupMonitoring},
+			CheckID:           CheckIDGrpcTLS,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "Verify the Senzing gRPC server answers and report its latency and version",
+			CheckFunc:         checkself.CheckGrpc,
+			CheckGroups:       []string{CheckGroupEngine},
+			CheckID:           CheckIDGrpc,
+		},
+		&SimpleChecker{
+			CheckDependencies: nil,
+			CheckDescription:  "Verify the observer answers and accepts messages",
+			CheckFunc:         checkself.CheckObserver,
+			CheckGroups:       []string{CheckGroupMonitoring},
+			CheckID:           CheckIDObserver,
+		},
+		&SimpleChecker{
+			CheckDependencies: []string{CheckIDDatabaseURL},
+			CheckDescription:  "Verify the Senzing schema is installed in the database",
+			CheckFunc:         checkself.CheckDatabaseSchema,
+			CheckGroups:       []string{CheckGroupDatabase},
+			CheckID:           CheckIDDatabaseSchema,
+		},
+		&SimpleChecker{
+			CheckDependencies: []string{CheckIDDatabaseSchema},
+			CheckDescription:  "Verify the database user holds the privileges Senzing needs on every core table",
+			CheckFunc:         checkself.CheckDatabasePrivileges,
+			CheckGroups:       []string{CheckGroupDatabase},
+			CheckID:           CheckIDDatabasePrivileges,
+		},
+		&SimpleChecker{
+			CheckDependencies: []string{CheckIDDatabaseSchema},
+			CheckDescription:  "Compare each database with the Senzing schema DDL shipped in the resource path",
+			CheckFunc:         checkself.CheckDatabaseSchemaDrift,
+			CheckGroups:       []string{CheckGroupDatabase},
+			CheckID:           CheckIDDatabaseSchemaDrift,
+		},
+		&SimpleChecker{
+			CheckDependencies: []string{CheckIDDatabaseURL},
+			CheckDescription:  "Check SQLite file permissions, integrity, journals, locking, and location",
+			CheckFunc:         checkself.CheckDatabaseSQLite,
+			CheckGroups:       []string{CheckGroupDatabase},
+			CheckID:           CheckIDDatabaseSQLite,
+		},
+		&SimpleChecker{
+			CheckDependencies: []string{CheckIDDatabaseSchema},
+			CheckDescription:  "Report row counts, records by data source, and size on disk of each database",
+			CheckFunc:         checkself.CheckRepositoryStatistics,
+			CheckGroups:       []string{CheckGroupDatabase, CheckGroupInfo},
+			CheckID:           CheckIDRepositoryStatistics,
+		},
+		&SimpleChecker{
+			CheckDependencies: []string{CheckIDDatabaseURL},
+			CheckDescription:  "Verify database connections are encrypted with trusted, unexpired certificates",
+			CheckFunc:         checkself.CheckDatabaseTLS,
+			CheckGroups:       []string{CheckGroupDatabase},
+			CheckID:           CheckIDDatabaseTLS,
+		},
+		&engineChecker{
+			SimpleChecker: SimpleChecker{
+				CheckDependencies: nil,
+				CheckDescription:  "Verify the Senzing configuration exists",
+				CheckFunc:         checkself.CheckSenzingConfiguration,
+				CheckGroups:       []string{CheckGroupEngine},
+				CheckID:           CheckIDSenzingConfiguration,
+			},
+			checkself:         checkself,
+			localDependencies: []string{CheckIDDatabaseSchema},
+		},
+		&engineChecker{
+			SimpleChecker: SimpleChecker{
+				CheckDependencies: nil,
+				CheckDescription:  "Verify the Senzing engine is the expected version",
+				CheckFunc:         checkself.CheckSenzingVersion,
+				CheckGroups:       []string{CheckGroupEngine, CheckGroupInfo},
+				CheckID:           CheckIDSenzingVersion,
+			},
+			checkself:         checkself,
+			localDependencies: nil,
+		},
+		&SimpleChecker{
+			CheckDependencies: []string{CheckIDSenzingConfiguration},
+			CheckDescription:  "Report the data stores the Senzing engine uses",
+			CheckFunc:         checkself.CheckSenzingDiagnostic,
+			CheckGroups:       []string{CheckGroupEngine, CheckGroupInfo},
+			CheckID:           CheckIDSenzingDiagnostic,
+		},
+		&engineChecker{
+			SimpleChecker: SimpleChecker{
+				CheckDependencies: nil,
+				CheckDescription:  "Check the Senzing license expiry and record limit",
+				CheckFunc:         checkself.CheckLicense,
+				CheckGroups:       []string{CheckGroupLicense},
+				CheckID:           CheckIDLicense,
+			},
+			checkself:         checkself,
+			localDependencies: []string{CheckIDDatabaseSchema},
+		},
+	}
+}
+
+func (checkself *BasicCheckSelf) getCheckers() []Checker {
+	if checkself.checkers == nil {
+		checkself.checkers = checkself.defaultCheckers()
+	}
+
+	return checkself.checkers
+}
+
+/*
+The selectedCheckers method returns the registered checkers, in registry order,
+named by SelectedChecks (default: all) and not named by SkippedChecks.
+*/
+func (checkself *BasicCheckSelf) selectedCheckers() ([]Checker, error) {
+	checkers := checkself.getCheckers()
+
+	selectedNames := splitCheckNames(checkself.SelectedChecks)
+	if len(selectedNames) > 0 {
+		var err error
+
+		checkers, err = matchCheckers(checkers, selectedNames)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "SelectedChecks")
+		}
+	}
+
+	skipped, err := matchCheckers(checkself.getCheckers(), splitCheckNames(checkself.SkippedChecks))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "SkippedChecks")
+	}
+
+	return slices.DeleteFunc(slices.Clone(checkers), func(checker Checker) bool {
+		return slices.Contains(skipped, checker)
+	}), nil
+}
+
+func (checkself *BasicCheckSelf) indexOfChecker(checkerID string) int {
+	return slices.IndexFunc(checkself.getCheckers(), func(checker Checker) bool {
+		return checker.ID() == checkerID
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// matchCheckers returns the checkers whose ID or one of whose groups is in names.
+func matchCheckers(checkers []Checker, names []string) ([]Checker, error) {
+	knownGroups := []string{
+		CheckGroupDatabase,
+		CheckGroupEngine,
+		CheckGroupInfo,
+		CheckGroupLicense,
+		CheckGroupMonitoring,
+		CheckGroupPaths,
+	}
+
+	for _, name := range names {
+		isKnown := slices.Contains(knownGroups, name) || slices.ContainsFunc(checkers, func(checker Checker) bool {
+			return checker.ID() == name || slices.Contains(checker.Groups(), name)
+		})
+		if !isKnown {
+			return nil, wraperror.Errorf(errForPackage, "unknown check or group: %s", name)
+		}
+	}
+
+	result := slices.DeleteFunc(slices.Clone(checkers), func(checker Checker) bool {
+		return !slices.Contains(names, checker.ID()) && !slices.ContainsFunc(checker.Groups(), func(group string) bool {
+			return slices.Contains(names, group)
+		})
+	})
+
+	return result, nil
+}
+
+// splitCheckNames accepts both repeated values and comma-separated lists (e.g. from SENZING_TOOLS_* variables).
+func splitCheckNames(values []string) []string {
+	var result []string
+
+	for _, value := range values {
+		for name := range strings.SplitSeq(value, ",") {
+			name = strings.TrimSpace(name)
+			if len(name) > 0 {
+				result = append(result, name)
+			}
+		}
+	}
+
+	return result
+}
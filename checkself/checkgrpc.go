@@ -0,0 +1,276 @@
+package checkself
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-sdk-abstract-factory/szfactorycreator"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// grpcEndpoint is the server named by a gRPC URL.
+type grpcEndpoint struct {
+	host   string
+	port   string
+	scheme string // grpcScheme, or grpcsScheme to require TLS.
+}
+
+// grpcAbstractFactory is a gRPC senzing.SzAbstractFactory that owns its connection.
+type grpcAbstractFactory struct {
+	senzing.SzAbstractFactory
+
+	connection *grpc.ClientConn
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CheckIDGrpc identifies the CheckGrpc check.
+const CheckIDGrpc = "grpc"
+
+const (
+	defaultGrpcPort = "8261"
+	grpcScheme      = "grpc"
+	grpcsScheme     = "grpcs"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckGrpc method verifies the Senzing gRPC server at GrpcURL answers before
+the checks that call the engine through it.  It reports the state of the
+connection, the round-trip latency of a request, and the version of Senzing the
+server runs.  If GrpcURL is not set, there is nothing to check.
+*/
+func (checkself *BasicCheckSelf) CheckGrpc(ctx context.Context, report *Report) error {
+	if len(checkself.GrpcURL) == 0 {
+		report.AddCheck("Check Senzing gRPC server: not used; GrpcURL is not set")
+
+		return nil
+	}
+
+	report.AddCheck("Check Senzing gRPC server: %s = %s", option.GrpcURL.Envar, checkself.GrpcURL)
+
+	connection, err := checkself.newGrpcConnection(checkself.GrpcURL, checkself.GrpcDialOptions)
+	if err != nil {
+		report.addFindings(CheckIDGrpc, newError(
+			option.GrpcURL.Envar,
+			checkself.GrpcURL,
+			"Could not create a gRPC client.",
+			err,
+		))
+
+		return nil
+	}
+
+	defer connection.Close()
+
+	report.addFindings(CheckIDGrpc, checkself.probeGrpcServer(ctx, connection)...)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// grpcEndpoint methods
+// ----------------------------------------------------------------------------
+
+// target returns the host:port to dial.
+func (endpoint grpcEndpoint) target() string {
+	return net.JoinHostPort(endpoint.host, endpoint.port)
+}
+
+// ----------------------------------------------------------------------------
+// grpcAbstractFactory methods
+// ----------------------------------------------------------------------------
+
+// Close closes the factory and then its connection.
+func (factory *grpcAbstractFactory) Close(ctx context.Context) error {
+	err := errors.Join(factory.SzAbstractFactory.Close(ctx), factory.connection.Close())
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (checkself *BasicCheckSelf) createGrpcAbstractFactory() (senzing.SzAbstractFactory, error) {
+	connection, err := checkself.newGrpcConnection(checkself.GrpcURL, checkself.GrpcDialOptions)
+	if err != nil {
+		return nil, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	szAbstractFactory, err := szfactorycreator.CreateGrpcAbstractFactory(connection)
+	if err != nil {
+		return nil, wraperror.Errorf(errors.Join(err, connection.Close()), wraperror.NoMessage)
+	}
+
+	return &grpcAbstractFactory{SzAbstractFactory: szAbstractFactory, connection: connection}, nil
+}
+
+/*
+The engineDependencies method returns the checks that must pass before a check
+that calls the Senzing engine: CheckGrpc if GrpcURL is set, otherwise the
+engine settings, the native library, and localDependencies.  Over gRPC, the
+server opens the database, so localDependencies do not apply.
+*/
+func (checkself *BasicCheckSelf) engineDependencies(localDependencies ...string) []string {
+	if len(checkself.GrpcURL) > 0 {
+		return []string{CheckIDGrpc}
+	}
+
+	return append([]string{CheckIDSettings, CheckIDEngine}, localDependencies...)
+}
+
+/*
+The newGrpcConnection method creates a client of the server at grpcURL (e.g.
+GrpcURL or ObserverURL).  It connects on first use.  Unless dialOptions are
+given, the connection uses TLS as described by getGrpcTLSSettings, or no
+transport security.
+*/
+func (checkself *BasicCheckSelf) newGrpcConnection(
+	grpcURL string,
+	dialOptions []grpc.DialOption,
+) (*grpc.ClientConn, error) {
+	endpoint, err := parseGrpcURL(grpcURL)
+	if err != nil {
+		return nil, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	if len(dialOptions) == 0 {
+		transportCredentials := insecure.NewCredentials()
+
+		if checkself.getGrpcTLSSettings(endpoint).enabled {
+			tlsConfig, err := checkself.newGrpcTLSConfig(endpoint.host)
+			if err != nil {
+				return nil, wraperror.Errorf(err, wraperror.NoMessage)
+			}
+
+			transportCredentials = credentials.NewTLS(tlsConfig)
+		}
+
+		dialOptions = []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials)}
+	}
+
+	result, err := grpc.NewClient(endpoint.target(), dialOptions...)
+
+	return result, wraperror.Errorf(err, "gRPC client of %s", endpoint.target())
+}
+
+/*
+The probeGrpcServer method asks the server for its version twice: the first
+request connects, the second measures the round trip.  The SzProduct and
+SzAbstractFactory are released before returning; connection is left to the
+caller.
+*/
+func (checkself *BasicCheckSelf) probeGrpcServer(
+	ctx context.Context,
+	connection *grpc.ClientConn,
+) (result []Finding) {
+	subject, value := option.GrpcURL.Envar, checkself.GrpcURL
+
+	szAbstractFactory, err := szfactorycreator.CreateGrpcAbstractFactory(connection)
+	if err != nil {
+		return append(result, newError(subject, value, "Could not create SzAbstractFactory.", err))
+	}
+
+	defer func() {
+		err := szAbstractFactory.Close(ctx)
+		if err != nil {
+			result = append(result, newWarning(subject, value, "Could not close SzAbstractFactory.", err))
+		}
+	}()
+
+	szProduct, err := szAbstractFactory.CreateProduct(ctx)
+	if err != nil {
+		return append(result, newError(subject, value, "Could not create SzProduct.", err))
+	}
+
+	defer func() {
+		err := szProduct.Destroy(ctx)
+		if err != nil {
+			result = append(result, newWarning(subject, value, "Could not destroy SzProduct.", err))
+		}
+	}()
+
+	_, err = szProduct.GetVersion(ctx)
+	if err != nil {
+		return append(result, newError(subject, value, fmt.Sprintf(
+			"Could not reach the Senzing gRPC server; connection state %s.",
+			connection.GetState(),
+		), err))
+	}
+
+	start := time.Now()
+	versionJSON, err := szProduct.GetVersion(ctx)
+	latency := time.Since(start)
+
+	if err != nil {
+		return append(result, newError(subject, value, fmt.Sprintf(
+			"Senzing gRPC server stopped answering; connection state %s.",
+			connection.GetState(),
+		), err))
+	}
+
+	summary := fmt.Sprintf(
+		"gRPC: %s is %s; round trip %s",
+		connection.Target(),
+		connection.GetState(),
+		latency.Round(time.Microsecond),
+	)
+
+	version, err := parseBuildVersion([]byte(versionJSON), "SzProduct.GetVersion")
+	if err != nil {
+		return append(result,
+			newWarning(subject, value, "Could not read the version of the Senzing gRPC server.", err),
+			newInfo(subject, summary+"."),
+		)
+	}
+
+	return append(result, newInfo(
+		subject,
+		fmt.Sprintf("%s; Senzing %s (build %s).", summary, version.Version, version.BuildVersion),
+	))
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// parseGrpcURL parses a URL like grpc://localhost:8261 or grpcs://localhost:8261.  The port defaults to 8261.
+func parseGrpcURL(grpcURL string) (grpcEndpoint, error) {
+	var result grpcEndpoint
+
+	parsedURL, err := url.Parse(grpcURL)
+	if err != nil {
+		return result, wraperror.Errorf(err, "parse %s", grpcURL)
+	}
+
+	isGrpc := parsedURL.Scheme == grpcScheme || parsedURL.Scheme == grpcsScheme
+	if !isGrpc || len(parsedURL.Hostname()) == 0 {
+		return result, wraperror.Errorf(errForPackage, "%s is not a gRPC URL (e.g. grpc://localhost:8261)", grpcURL)
+	}
+
+	result = grpcEndpoint{host: parsedURL.Hostname(), port: parsedURL.Port(), scheme: parsedURL.Scheme}
+	if len(result.port) == 0 {
+		result.port = defaultGrpcPort
+	}
+
+	return result, nil
+}
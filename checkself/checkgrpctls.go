@@ -0,0 +1,250 @@
+package checkself
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"google.golang.org/grpc"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CheckIDGrpcTLS identifies the CheckGrpcTLS check.
+const CheckIDGrpcTLS = "grpc-tls"
+
+const http2Protocol = "h2"
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckGrpcTLS method reports whether the connections to GrpcURL and
+ObserverURL are encrypted.  For each encrypted connection it performs the TLS
+handshake, verifies the server certificate chain and host name against
+ClientCACertificateFile or the system roots, reports whether the server asks
+for a client certificate (mTLS), and warns about certificates, including
+ClientCertificateFile, that expire within CertificateDaysLeft days.  Under the
+production Policy, unencrypted connections are errors.  If GrpcDialOptions is
+set, the connection to GrpcURL is not checked.
+*/
+func (checkself *BasicCheckSelf) CheckGrpcTLS(ctx context.Context, report *Report) error {
+	endpoints := []struct {
+		dialOptions []grpc.DialOption
+		url         string
+		variable    string
+	}{
+		{dialOptions: checkself.GrpcDialOptions, url: checkself.GrpcURL, variable: option.GrpcURL.Envar},
+		{dialOptions: nil, url: checkself.ObserverURL, variable: option.ObserverURL.Envar},
+	}
+
+	isChecked := false
+
+	for _, endpoint := range endpoints {
+		if len(endpoint.url) == 0 {
+			continue
+		}
+
+		isChecked = true
+
+		report.AddCheck("Check gRPC TLS: %s = %s", endpoint.variable, endpoint.url)
+		report.addFindings(
+			CheckIDGrpcTLS,
+			checkself.checkGrpcTLS(ctx, endpoint.variable, endpoint.url, endpoint.dialOptions)...,
+		)
+	}
+
+	if !isChecked {
+		report.AddCheck("Check gRPC TLS: not used; GrpcURL and ObserverURL are not set")
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+The checkGrpcTLS method checks the TLS of one gRPC connection.  Transport
+security set by dialOptions (e.g. GrpcDialOptions) cannot be inspected, so it
+is noted and not checked.
+*/
+func (checkself *BasicCheckSelf) checkGrpcTLS(
+	ctx context.Context,
+	variableName string,
+	grpcURL string,
+	dialOptions []grpc.DialOption,
+) []Finding {
+	var result []Finding
+
+	endpoint, err := parseGrpcURL(grpcURL)
+	if err != nil {
+		return append(result, newError(variableName, grpcURL, "Could not parse the gRPC URL.", err))
+	}
+
+	if len(dialOptions) > 0 {
+		return append(result, newInfo(variableName, "TLS: not checked; the connection uses GrpcDialOptions."))
+	}
+
+	settings := checkself.getGrpcTLSSettings(endpoint)
+	if !settings.enabled {
+		return append(result, checkself.unencrypted(variableName, settings))
+	}
+
+	tlsConfig, err := checkself.newGrpcTLSConfig(endpoint.host)
+	if err != nil {
+		return append(result, newError(variableName, "", "TLS: could not load the client TLS files.", err))
+	}
+
+	state, isClientCertificateRequested, err := handshakeGrpcTLS(ctx, endpoint.target(), tlsConfig)
+	if err != nil && isClientCertificateRequested && len(tlsConfig.Certificates) == 0 {
+		return append(result, newError(
+			variableName,
+			"",
+			"TLS: could not complete the handshake with the server. "+
+				"The server asks for a client certificate (mTLS), but ClientCertificateFile is not set.",
+			err,
+		))
+	}
+
+	if err != nil {
+		return append(result, newError(variableName, "", "TLS: could not complete the handshake with the server.", err))
+	}
+
+	result = append(result, newInfo(
+		variableName,
+		"TLS: connection is encrypted using "+tls.VersionName(state.Version)+".",
+	))
+	result = append(result, verifyCertificates(variableName, state, settings, endpoint.host)...)
+
+	switch {
+	case isClientCertificateRequested && len(tlsConfig.Certificates) == 0:
+		result = append(result, newWarning(
+			variableName,
+			"",
+			"TLS: server asks for a client certificate (mTLS), but ClientCertificateFile is not set.",
+			nil,
+		))
+	case isClientCertificateRequested:
+		result = append(result, newInfo(variableName, "TLS: client certificate presented (mTLS)."))
+	}
+
+	certificates := state.PeerCertificates
+	if len(tlsConfig.Certificates) > 0 && tlsConfig.Certificates[0].Leaf != nil {
+		certificates = append(certificates, tlsConfig.Certificates[0].Leaf)
+	}
+
+	return append(result, checkCertificateExpiry(variableName, certificates, checkself.getCertificateDaysLeft())...)
+}
+
+/*
+The getGrpcTLSSettings method returns how a gRPC URL asks for TLS: by the
+grpcs scheme, or by setting ClientCACertificateFile or ClientCertificateFile.
+TLS connections always verify the server certificate and host name.
+*/
+func (checkself *BasicCheckSelf) getGrpcTLSSettings(endpoint grpcEndpoint) tlsSettings {
+	return tlsSettings{
+		caFile: checkself.ClientCACertificateFile,
+		enabled: endpoint.scheme == grpcsScheme ||
+			len(checkself.ClientCACertificateFile) > 0 ||
+			len(checkself.ClientCertificateFile) > 0,
+		option:     endpoint.scheme + "://",
+		required:   true,
+		verify:     true,
+		verifyHost: true,
+	}
+}
+
+// newGrpcTLSConfig returns the client TLS configuration for host from the Client* files.
+func (checkself *BasicCheckSelf) newGrpcTLSConfig(host string) (*tls.Config, error) {
+	result := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: host,
+	}
+
+	if len(checkself.ClientCACertificateFile) > 0 {
+		caPEM, err := os.ReadFile(checkself.ClientCACertificateFile)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "read ClientCACertificateFile")
+		}
+
+		result.RootCAs = x509.NewCertPool()
+		if !result.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, wraperror.Errorf(
+				errForPackage,
+				"no certificates found in ClientCACertificateFile %s",
+				checkself.ClientCACertificateFile,
+			)
+		}
+	}
+
+	if len(checkself.ClientCertificateFile) > 0 || len(checkself.ClientKeyFile) > 0 {
+		certificate, err := tls.LoadX509KeyPair(checkself.ClientCertificateFile, checkself.ClientKeyFile)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "load ClientCertificateFile and ClientKeyFile")
+		}
+
+		result.Certificates = []tls.Certificate{certificate}
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+/*
+The handshakeGrpcTLS function performs the TLS handshake with a gRPC server
+without verifying its certificate, so the certificate can be examined.
+isClientCertificateRequested is true if the server asks for a client certificate.
+*/
+func handshakeGrpcTLS(
+	ctx context.Context,
+	target string,
+	tlsConfig *tls.Config,
+) (*tls.ConnectionState, bool, error) {
+	var dialer net.Dialer
+
+	dialer.Timeout = defaultDialTimeout
+
+	connection, err := dialer.DialContext(ctx, "tcp", target)
+	if err != nil {
+		return nil, false, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	defer connection.Close()
+
+	isClientCertificateRequested := false
+	handshakeConfig := tlsConfig.Clone()
+	handshakeConfig.InsecureSkipVerify = true // #nosec G402 -- The certificate is verified by verifyCertificates.
+	handshakeConfig.NextProtos = []string{http2Protocol}
+	handshakeConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		isClientCertificateRequested = true
+
+		if len(tlsConfig.Certificates) == 0 {
+			return &tls.Certificate{}, nil
+		}
+
+		return &tlsConfig.Certificates[0], nil
+	}
+
+	tlsConnection := tls.Client(connection, handshakeConfig)
+
+	err = tlsConnection.HandshakeContext(ctx)
+	if err != nil {
+		return nil, isClientCertificateRequested, wraperror.Errorf(err, "TLS handshake")
+	}
+
+	state := tlsConnection.ConnectionState()
+
+	return &state, isClientCertificateRequested, nil
+}
@@ -8,106 +8,120 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/senzing-garage/go-cmdhelping/option"
 	"github.com/senzing-garage/go-databasing/checker"
 	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
 )
 
+// CheckIDLicense identifies the CheckLicense check.
+const CheckIDLicense = "license"
+
 const (
-	hoursPerDay = 24
+	hoursPerDay        = 24
+	unknownRecordCount = -1
 )
 
 // ----------------------------------------------------------------------------
 // Interface methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) CheckLicense(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
+/*
+The CheckLicense method reports the Senzing license and warns when it is about
+to expire or its record limit is nearly used.  If GrpcURL is set, the records
+are in the database of the gRPC server, so the record limit is not checked.
+*/
+func (checkself *BasicCheckSelf) CheckLicense(ctx context.Context, report *Report) error {
 	var err error
 
-	reportChecks = append(reportChecks, "Check Senzing license")
+	report.AddCheck("Check Senzing license")
 
-	recordCount, err := checkself.getRecordCount(ctx)
-	if err != nil {
-		return returnValues(reportChecks, reportInfo, reportErrors, err, "getRecordCount")
+	recordCount := int64(unknownRecordCount)
+
+	if len(checkself.GrpcURL) > 0 {
+		report.addFindings(CheckIDLicense, newInfo(
+			option.GrpcURL.Envar,
+			"License record limit not checked: the records are in the database of the Senzing gRPC server.",
+		))
+	} else {
+		recordCount, err = checkself.getRecordCount(ctx)
+		if err != nil {
+			return addLicenseError(report, err, "Could not get record count.")
+		}
 	}
 
 	license, err := checkself.getLicense(ctx)
 	if err != nil {
-		return returnValues(reportChecks, reportInfo, reportErrors, err, "getLicense")
+		return addLicenseError(report, err, "Could not get license.")
 	}
 
 	productLicenseResponse, err := getProductLicenseResponse(license)
 	if err != nil {
-		return returnValues(reportChecks, reportInfo, reportErrors, err, "getProductLicenseResponse")
+		return addLicenseError(report, err, "Could not parse license.")
 	}
 
 	prettyJSON, err := getPrettyJSON(license)
 	if err != nil {
-		return returnValues(reportChecks, reportInfo, reportErrors, err, "getPrettyJSON")
+		return addLicenseError(report, err, "Could not format license.")
 	}
 
 	expireInDays, err := getExpireInDays(productLicenseResponse)
 	if err != nil {
-		return returnValues(reportChecks, reportInfo, reportErrors, err, "getExpireInDays")
+		return addLicenseError(report, err, "Could not determine license expiration.")
 	}
 
-	expiryErrors, err := checkself.checkExpiry(expireInDays)
+	expiryFindings, err := checkself.checkExpiry(expireInDays)
 	if err != nil {
-		return returnValues(reportChecks, reportInfo, reportErrors, err, "checkExpiry")
+		return addLicenseError(report, err, "Could not check license expiration.")
 	}
 
-	recordPercentErrors, err := checkself.checkRecordPercent(recordCount, productLicenseResponse)
+	recordPercentFindings, err := checkself.checkRecordPercent(recordCount, productLicenseResponse)
 	if err != nil {
-		return returnValues(reportChecks, reportInfo, reportErrors, err, "checkRecordPercent")
+		return addLicenseError(report, err, "Could not check license record limit.")
 	}
 
-	reportInfo = append(reportInfo,
-		buildReportInfo(
-			recordCount,
-			productLicenseResponse,
-			expireInDays,
-			prettyJSON.String(),
-		)...)
-
-	reportErrors = append(reportErrors, expiryErrors...)
-	reportErrors = append(reportErrors, recordPercentErrors...)
+	report.AddInfo("%s", buildReportInfo(recordCount, productLicenseResponse, expireInDays, prettyJSON.String()))
+	report.addFindings(CheckIDLicense, expiryFindings...)
+	report.addFindings(CheckIDLicense, recordPercentFindings...)
 
 	// Epilog.
 
-	return reportChecks, reportInfo, reportErrors, nil
+	return nil
 }
 
 // ----------------------------------------------------------------------------
 // Private methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) checkExpiry(expireInDays int) ([]string, error) {
-	var result []string
+func (checkself *BasicCheckSelf) checkExpiry(expireInDays int) ([]Finding, error) {
+	var result []Finding
+
+	// Use a local copy so concurrent runs do not modify the BasicCheckSelf.
 
-	if len(checkself.ErrorLicenseDaysLeft) == 0 {
-		checkself.ErrorLicenseDaysLeft = DefaultSenzingToolsLicenseDaysLeft
+	licenseDaysLeft := checkself.ErrorLicenseDaysLeft
+	if len(licenseDaysLeft) == 0 {
+		licenseDaysLeft = DefaultSenzingToolsLicenseDaysLeft
 	}
 
-	errorLicenseDaysLeft, err := strconv.Atoi(checkself.ErrorLicenseDaysLeft)
+	errorLicenseDaysLeft, err := strconv.Atoi(licenseDaysLeft)
 	if err != nil {
 		return result, wraperror.Errorf(
 			err,
 			"Could not parse SENZING_TOOLS_LICENSE_DAYS_LEFT information: %s",
-			checkself.ErrorLicenseDaysLeft,
+			licenseDaysLeft,
 		)
 	}
 
-	if expireInDays < errorLicenseDaysLeft {
+	switch {
+	case expireInDays < 0:
+		result = append(
+			result,
+			newCritical("", "", fmt.Sprintf("License expired %d days ago.", -expireInDays), nil),
+		)
+	case expireInDays < errorLicenseDaysLeft:
 		result = append(
 			result,
-			fmt.Sprintf(
-				"License expires in %d days. For more information, visit https://hub.senzing.com/... ",
-				expireInDays,
-			),
+			newWarning("", "", fmt.Sprintf("License expires in %d days.", expireInDays), nil),
 		)
 	}
 
@@ -120,22 +134,16 @@ func (checkself *BasicCheckSelf) getLicense(ctx context.Context) (string, error)
 		result string
 	)
 
-	szProduct, err := checkself.createSzProduct(ctx)
-	if err != nil {
-		return result, wraperror.Errorf(err, "Could not create szProduct")
-	}
+	engineMutex.Lock()
+	defer engineMutex.Unlock()
 
-	defer func() {
-		err := szProduct.Destroy(ctx)
-		if err != nil {
-			panic(err)
-		}
-	}()
+	err = checkself.withSzProduct(ctx, func(szProduct senzing.SzProduct) error {
+		var err error
 
-	result, err = szProduct.GetLicense(ctx)
-	if err != nil {
-		return result, wraperror.Errorf(err, "Could not get license information")
-	}
+		result, err = szProduct.GetLicense(ctx)
+
+		return wraperror.Errorf(err, "Could not get license information")
+	})
 
 	return result, wraperror.Errorf(err, wraperror.NoMessage)
 }
@@ -143,31 +151,32 @@ func (checkself *BasicCheckSelf) getLicense(ctx context.Context) (string, error)
 func (checkself *BasicCheckSelf) checkRecordPercent(
 	recordCount int64,
 	productLicenseResponse *ProductLicenseResponse,
-) ([]string, error) {
-	var result []string
+) ([]Finding, error) {
+	var result []Finding
 
-	if len(checkself.ErrorLicenseRecordsPercent) == 0 {
-		checkself.ErrorLicenseRecordsPercent = DefaultSenzingToolsLicenseRecordsPercent
+	licenseRecordsPercent := checkself.ErrorLicenseRecordsPercent
+	if len(licenseRecordsPercent) == 0 {
+		licenseRecordsPercent = DefaultSenzingToolsLicenseRecordsPercent
 	}
 
-	errorLicenseRecordsPercent, err := strconv.Atoi(checkself.ErrorLicenseRecordsPercent)
+	errorLicenseRecordsPercent, err := strconv.Atoi(licenseRecordsPercent)
 	if err != nil {
 		return result, wraperror.Errorf(
 			err,
 			"Could not parse SENZING_TOOLS_LICENSE_RECORDS_PERCENT information: %s.",
-			checkself.ErrorLicenseRecordsPercent,
+			licenseRecordsPercent,
 		)
 	}
 
-	if productLicenseResponse.RecordLimit != 0 {
-		if (recordCount / productLicenseResponse.RecordLimit) > int64(errorLicenseRecordsPercent) {
-			result = append(
-				result,
-				fmt.Sprintf(
-					"Records above %d full limit. For more information, visit https://hub.senzing.com/... ",
-					errorLicenseRecordsPercent,
-				),
-			)
+	if productLicenseResponse.RecordLimit != 0 && recordCount != unknownRecordCount {
+		recordsPercent := recordCount * 100 / productLicenseResponse.RecordLimit
+		if recordsPercent >= int64(errorLicenseRecordsPercent) {
+			result = append(result, newWarning("", "", fmt.Sprintf(
+				"Records are at %d%% of the license limit (%d of %d).",
+				recordsPercent,
+				recordCount,
+				productLicenseResponse.RecordLimit,
+			), nil))
 		}
 	}
 
@@ -208,19 +217,20 @@ func buildReportInfo(
 	productLicenseResponse *ProductLicenseResponse,
 	expireInDays int,
 	prettyJSON string,
-) []string {
-	result := []string{
-		fmt.Sprintf(`
+) string {
+	recordsUsed := strconv.FormatInt(recordCount, 10)
+	if recordCount == unknownRecordCount {
+		recordsUsed = "not counted"
+	}
+
+	return fmt.Sprintf(`
 License:
 
-- Records used: %d of %d
+- Records used: %s of %d
 - Date license expires: %s
 - Days until license expires: %d
 
-%s`, recordCount, productLicenseResponse.RecordLimit, productLicenseResponse.ExpireDate, expireInDays, prettyJSON),
-	}
-
-	return result
+%s`, recordsUsed, productLicenseResponse.RecordLimit, productLicenseResponse.ExpireDate, expireInDays, prettyJSON)
 }
 
 func getExpireInDays(productLicenseResponse *ProductLicenseResponse) (int, error) {
@@ -259,17 +269,8 @@ func getProductLicenseResponse(license string) (*ProductLicenseResponse, error)
 	return result, wraperror.Errorf(err, wraperror.NoMessage)
 }
 
-func returnValues(
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-	err error,
-	message string,
-) ([]string, []string, []string, error) {
-	if err != nil {
-		errorMessage := fmt.Sprintf("%s: %s", message, err.Error())
-		reportErrors = append(reportErrors, errorMessage)
-	}
+func addLicenseError(report *Report, err error, message string) error {
+	report.addFindings(CheckIDLicense, newError("", "", message, err))
 
-	return reportChecks, reportInfo, reportErrors, nil
+	return nil
 }
@@ -0,0 +1,178 @@
+package checkself
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-observing/observerpb"
+	"google.golang.org/grpc"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CheckIDObserver identifies the CheckObserver check.
+const CheckIDObserver = "observer"
+
+// Message IDs of the messages check-self sends to ObserverURL.  Their subjectId is ObserverSubjectID.
+const (
+	ObserverMessageIDProbe  = 1 // Sent by CheckObserver; needs no action.
+	ObserverMessageIDReport = 2 // Sent by PublishReport; details "status" and "report" (a JSONReport).
+)
+
+const observerSubjectIDVariable = "SENZING_TOOLS_OBSERVER_SUBJECT_ID"
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckObserver method verifies the observer at ObserverURL is reachable and
+accepts messages by sending it a message with ObserverMessageIDProbe.  It
+reports the state of the connection and the round-trip latency.  If
+ObserverURL is not set, there is nothing to check.
+
+check-self has no registered Senzing component ID, so messages are sent only
+when ObserverSubjectID gives the subjectId to send them under.
+*/
+func (checkself *BasicCheckSelf) CheckObserver(ctx context.Context, report *Report) error {
+	if len(checkself.ObserverURL) == 0 {
+		report.AddCheck("Check observer: not used; ObserverURL is not set")
+
+		return nil
+	}
+
+	report.AddCheck("Check observer: %s = %s", option.ObserverURL.Envar, checkself.ObserverURL)
+
+	subject, value := option.ObserverURL.Envar, checkself.ObserverURL
+
+	if len(checkself.ObserverSubjectID) == 0 {
+		report.addFindings(CheckIDObserver, newError(
+			observerSubjectIDVariable,
+			"",
+			"Observer: no subjectId to send messages under; set it to the component ID registered for check-self.",
+			nil,
+		))
+
+		return nil
+	}
+
+	connection, err := checkself.newGrpcConnection(checkself.ObserverURL, nil)
+	if err != nil {
+		report.addFindings(CheckIDObserver, newError(subject, value, "Could not create a gRPC client.", err))
+
+		return nil
+	}
+
+	defer connection.Close()
+
+	start := time.Now()
+	err = checkself.notifyObserver(ctx, connection, ObserverMessageIDProbe, map[string]any{
+		"message": "Probe from check-self; no action needed.",
+	})
+	latency := time.Since(start)
+
+	if err != nil {
+		report.addFindings(CheckIDObserver, newError(subject, value, fmt.Sprintf(
+			"Could not send a message to the observer; connection state %s.",
+			connection.GetState(),
+		), err))
+
+		return nil
+	}
+
+	report.addFindings(CheckIDObserver, newInfo(subject, fmt.Sprintf(
+		"Observer: %s is %s; accepted a message in %s.",
+		connection.Target(),
+		connection.GetState(),
+		latency.Round(time.Microsecond),
+	)))
+
+	return nil
+}
+
+/*
+The PublishReport method sends the report to the observer at ObserverURL as a
+message with ObserverMessageIDReport, so that observer-based monitoring
+receives the outcome of check-self.
+
+Input
+  - ctx: A context to control lifecycle.
+  - report: The report to send, usually from Run.  Secrets are masked even if ShowSecrets is set.
+
+Output
+  - An error if ObserverURL or ObserverSubjectID is not set or the observer did not accept the message.
+*/
+func (checkself *BasicCheckSelf) PublishReport(ctx context.Context, report *Report) error {
+	if len(checkself.ObserverURL) == 0 {
+		return wraperror.Errorf(errForPackage, "ObserverURL is not set")
+	}
+
+	if len(checkself.ObserverSubjectID) == 0 {
+		return wraperror.Errorf(errForPackage, "ObserverSubjectID is not set")
+	}
+
+	connection, err := checkself.newGrpcConnection(checkself.ObserverURL, nil)
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, checkself.getCheckTimeout())
+	defer cancel()
+
+	jsonReport := NewJSONReport(report)
+	jsonReport.redact()
+
+	err = checkself.notifyObserver(ctx, connection, ObserverMessageIDReport, map[string]any{
+		"report": jsonReport,
+		"status": jsonReport.Status,
+	})
+
+	return wraperror.Errorf(err, "publish report to %s", connection.Target())
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+The notifyObserver method sends a message in the form of the go-observing
+notifier: details plus origin (ObserverOrigin, if set), subjectId
+(ObserverSubjectID), messageId, and messageTime.
+*/
+func (checkself *BasicCheckSelf) notifyObserver(
+	ctx context.Context,
+	connection *grpc.ClientConn,
+	messageID int,
+	details map[string]any,
+) error {
+	message := maps.Clone(details)
+	if len(checkself.ObserverOrigin) > 0 {
+		message["origin"] = checkself.ObserverOrigin
+	}
+
+	message["subjectId"] = checkself.ObserverSubjectID
+	message["messageId"] = strconv.Itoa(messageID)
+	message["messageTime"] = time.Now().UTC().Format(time.RFC3339Nano)
+
+	messageJSON, err := json.Marshal(message)
+	if err != nil {
+		return wraperror.Errorf(err, "marshal observer message")
+	}
+
+	_, err = observerpb.NewObserverClient(connection).UpdateObserver(
+		ctx,
+		&observerpb.UpdateObserverRequest{Message: string(messageJSON)},
+	)
+
+	return wraperror.Errorf(err, "UpdateObserver")
+}
@@ -0,0 +1,280 @@
+package checkself
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// repositoryTable is a Senzing table whose rows are counted.
+type repositoryTable struct {
+	name  string // Statistic name (e.g. "Records").
+	query string // Counts the rows of the table.
+}
+
+// statisticsQueries are the dialect-specific queries of CheckRepositoryStatistics.
+type statisticsQueries struct {
+	configData   string // CONFIG_DATA of the SYS_CFG row whose CONFIG_DATA_ID is bound.
+	databaseSize string // Bytes the database occupies on disk.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CheckIDRepositoryStatistics identifies the CheckRepositoryStatistics check.
+const CheckIDRepositoryStatistics = "repository-statistics"
+
+const (
+	dataSourceCountsQuery = `SELECT DSRC_ID, COUNT(*) FROM DSRC_RECORD GROUP BY DSRC_ID ORDER BY DSRC_ID`
+	defaultConfigIDQuery  = `SELECT VAR_VALUE FROM SYS_VARS WHERE VAR_GROUP = 'CONFIG' AND VAR_CODE = 'DEFAULTCONFIGID'`
+)
+
+const (
+	unitBytes   = "bytes"
+	unitRecords = "records"
+	unitRows    = "rows"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var repositoryTables = []repositoryTable{
+	{name: "Records", query: `SELECT COUNT(*) FROM DSRC_RECORD`},
+	{name: "Entities", query: `SELECT COUNT(*) FROM RES_ENT`},
+	{name: "Observed entities", query: `SELECT COUNT(*) FROM OBS_ENT`},
+	{name: "Features", query: `SELECT COUNT(*) FROM LIB_FEAT`},
+}
+
+var statisticsQueriesByScheme = map[string]statisticsQueries{
+	"mssql": {
+		configData:   `SELECT CONFIG_DATA FROM SYS_CFG WHERE CONFIG_DATA_ID = @p1`,
+		databaseSize: `SELECT CAST(SUM(CAST(size AS BIGINT)) * 8192 AS BIGINT) FROM sys.database_files`,
+	},
+	"mysql": {
+		configData: `SELECT CONFIG_DATA FROM SYS_CFG WHERE CONFIG_DATA_ID = ?`,
+		databaseSize: `SELECT SUM(DATA_LENGTH + INDEX_LENGTH) FROM information_schema.TABLES
+WHERE TABLE_SCHEMA = DATABASE()`,
+	},
+	"oci":    oracleStatisticsQueries,
+	"oracle": oracleStatisticsQueries,
+	"postgresql": {
+		configData:   `SELECT CONFIG_DATA FROM SYS_CFG WHERE CONFIG_DATA_ID = $1`,
+		databaseSize: `SELECT pg_database_size(current_database())`,
+	},
+	"sqlite3": {
+		configData:   `SELECT CONFIG_DATA FROM SYS_CFG WHERE CONFIG_DATA_ID = ?`,
+		databaseSize: `SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()`,
+	},
+}
+
+var oracleStatisticsQueries = statisticsQueries{
+	configData:   `SELECT CONFIG_DATA FROM SYS_CFG WHERE CONFIG_DATA_ID = :1`,
+	databaseSize: `SELECT SUM(BYTES) FROM USER_SEGMENTS`,
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckRepositoryStatistics method records a snapshot of how big each
+database is: the rows in the main Senzing tables (records, entities, observed
+entities, features), the records loaded from each data source, and the size of
+the database on disk.  See Report.Statistics.
+*/
+func (checkself *BasicCheckSelf) CheckRepositoryStatistics(ctx context.Context, report *Report) error {
+	for _, database := range checkself.getDatabases() {
+		parsedURL, err := ParseDatabaseURL(database.URL)
+		if err != nil || parsedURL.Scheme == "db2" { // Reported by CheckDatabaseURL and CheckDatabaseSchema.
+			continue
+		}
+
+		report.AddCheck("Gather repository statistics for %s: %s", database.Name, database.URL)
+
+		statistics, findings := gatherRepositoryStatistics(ctx, database, statisticsQueriesByScheme[parsedURL.Scheme])
+		for _, statistic := range statistics {
+			statistic.Database = database.Name
+			report.AddStatistic(statistic)
+		}
+
+		report.addDatabaseFindings(CheckIDRepositoryStatistics, database, findings...)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// gatherRepositoryStatistics measures one database.  Measurements that fail are reported as warnings.
+func gatherRepositoryStatistics(
+	ctx context.Context,
+	database Database,
+	queries statisticsQueries,
+) ([]Statistic, []Finding) {
+	var (
+		findings []Finding
+		result   []Statistic
+	)
+
+	databaseConnector, err := newDatabaseConnector(ctx, database.URL)
+	if err != nil {
+		return result, append(findings, newError(
+			database.Variable,
+			database.URL,
+			"Could not create a database connector.",
+			err,
+		))
+	}
+
+	sqlDB := sql.OpenDB(databaseConnector)
+	defer sqlDB.Close()
+
+	for _, table := range repositoryTables {
+		var count int64
+
+		err = sqlDB.QueryRowContext(ctx, table.query).Scan(&count)
+		if err != nil {
+			findings = append(findings, newWarning(database.Variable, "", "Could not count "+table.name+".", err))
+
+			continue
+		}
+
+		result = append(result, newStatistic(table.name, count, unitRows))
+	}
+
+	dataSources, err := countDataSourceRecords(ctx, sqlDB, queries.configData)
+	if err != nil {
+		findings = append(findings, newWarning(database.Variable, "", "Could not count records by data source.", err))
+	}
+
+	result = append(result, dataSources...)
+
+	var size sql.NullInt64
+
+	err = sqlDB.QueryRowContext(ctx, queries.databaseSize).Scan(&size)
+	if err != nil {
+		return result, append(findings, newWarning(database.Variable, "", "Could not read the database size.", err))
+	}
+
+	if size.Valid {
+		result = append(result, newStatistic("Size on disk", size.Int64, unitBytes))
+	}
+
+	return result, findings
+}
+
+// countDataSourceRecords returns the number of records loaded from each data source.
+func countDataSourceRecords(ctx context.Context, sqlDB *sql.DB, configDataQuery string) ([]Statistic, error) {
+	var result []Statistic
+
+	rows, err := sqlDB.QueryContext(ctx, dataSourceCountsQuery)
+	if err != nil {
+		return result, wraperror.Errorf(err, "DSRC_RECORD")
+	}
+
+	defer rows.Close()
+
+	counts := map[int64]int64{}
+
+	var dataSourceIDs []int64
+
+	for rows.Next() {
+		var dataSourceID, count int64
+
+		err = rows.Scan(&dataSourceID, &count)
+		if err != nil {
+			return result, wraperror.Errorf(err, "scan DSRC_RECORD")
+		}
+
+		counts[dataSourceID] = count
+		dataSourceIDs = append(dataSourceIDs, dataSourceID)
+	}
+
+	err = rows.Err()
+	if err != nil || len(dataSourceIDs) == 0 {
+		return result, wraperror.Errorf(err, "DSRC_RECORD")
+	}
+
+	// A data source missing from the default configuration is reported by its DSRC_ID.
+
+	dataSourceCodes := getDataSourceCodes(ctx, sqlDB, configDataQuery)
+
+	for _, dataSourceID := range dataSourceIDs {
+		code, isOK := dataSourceCodes[dataSourceID]
+		if !isOK {
+			code = strconv.FormatInt(dataSourceID, 10)
+		}
+
+		result = append(result, newStatistic("Data source "+code, counts[dataSourceID], unitRecords))
+	}
+
+	return result, nil
+}
+
+/*
+The getDataSourceCodes function maps each DSRC_ID of the default Senzing
+configuration to its DSRC_CODE.  If the configuration cannot be read, the map
+is empty.
+*/
+func getDataSourceCodes(ctx context.Context, sqlDB *sql.DB, configDataQuery string) map[int64]string {
+	var (
+		configData   string
+		configID     string
+		parsedConfig struct {
+			G2Config struct {
+				CfgDsrc []struct {
+					DsrcCode string `json:"DSRC_CODE"`
+					DsrcID   int64  `json:"DSRC_ID"`
+				} `json:"CFG_DSRC"`
+			} `json:"G2_CONFIG"`
+		}
+	)
+
+	result := map[int64]string{}
+
+	err := sqlDB.QueryRowContext(ctx, defaultConfigIDQuery).Scan(&configID)
+	if err != nil {
+		return result
+	}
+
+	parsedConfigID, err := strconv.ParseInt(configID, 10, 64)
+	if err != nil {
+		return result
+	}
+
+	err = sqlDB.QueryRowContext(ctx, configDataQuery, parsedConfigID).Scan(&configData)
+	if err != nil {
+		return result
+	}
+
+	err = json.Unmarshal([]byte(configData), &parsedConfig)
+	if err != nil {
+		return result
+	}
+
+	for _, dataSource := range parsedConfig.G2Config.CfgDsrc {
+		result[dataSource.DsrcID] = dataSource.DsrcCode
+	}
+
+	return result
+}
+
+func newStatistic(name string, value int64, unit string) Statistic {
+	return Statistic{
+		Database: "",
+		Name:     name,
+		Unit:     unit,
+		Value:    value,
+	}
+}
@@ -2,11 +2,13 @@ package checkself
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/senzing-garage/go-cmdhelping/option"
 )
 
+// CheckIDResourcePath identifies the CheckResourcePath check.
+const CheckIDResourcePath = "resource-path"
+
 var RequiredResourceFiles = []string{
 	"templates/g2config.json",
 }
@@ -15,33 +17,25 @@ var RequiredResourceFiles = []string{
 // Interface methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) CheckResourcePath(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
+func (checkself *BasicCheckSelf) CheckResourcePath(ctx context.Context, report *Report) error {
 	_ = ctx
 
 	// Short-circuit exit.
 
 	if len(checkself.ResourcePath) == 0 {
-		return reportChecks, reportInfo, reportErrors, nil
+		return nil
 	}
 
 	// Prolog.
 
-	reportChecks = append(
-		reportChecks,
-		fmt.Sprintf("Check resource path: %s = %s", option.ResourcePath.Envar, checkself.ResourcePath),
-	)
+	report.AddCheck("Check resource path: %s = %s", option.ResourcePath.Envar, checkself.ResourcePath)
 
 	// Check Resource path.
 
-	errorList := statFiles(option.ResourcePath.Envar, checkself.ResourcePath, RequiredResourceFiles)
-	reportErrors = append(reportErrors, errorList...)
+	findings := statFiles(option.ResourcePath.Envar, checkself.ResourcePath, RequiredResourceFiles)
+	report.addFindings(CheckIDResourcePath, findings...)
 
 	// Epilog.
 
-	return reportChecks, reportInfo, reportErrors, nil
+	return nil
 }
@@ -3,13 +3,13 @@ package checkself
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"strings"
+	"time"
 
-	"github.com/senzing-garage/go-databasing/connector"
 	"github.com/senzing-garage/go-helpers/settings"
-	"github.com/senzing-garage/go-helpers/settingsparser"
 	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/senzing-garage/go-sdk-abstract-factory/szfactorycreator"
 	"github.com/senzing-garage/sz-sdk-go/senzing"
@@ -22,25 +22,51 @@ import (
 
 // BasicCheckSelf is the basic checker.
 type BasicCheckSelf struct {
+	CertificateDaysLeft        int           // Days before a TLS certificate expires to warn. Default: 30.
+	CheckTimeout               time.Duration // Limit for each check. Default: 30s.
+	ClientCACertificateFile    string        // PEM CAs that sign gRPC server certificates. Default: system roots.
+	ClientCertificateFile      string        // PEM certificate presented to gRPC servers (mTLS).
+	ClientKeyFile              string        // PEM private key of ClientCertificateFile.
+	Concurrency                int           // Maximum number of checks run at once. Default: 4.
 	ConfigPath                 string
 	DatabaseURL                string
 	EngineLogLevel             string // IMPROVE:
 	ErrorLicenseDaysLeft       string
 	ErrorLicenseRecordsPercent string
-	GrpcDialOptions            []grpc.DialOption // IMPROVE:
-	GrpcURL                    string            // IMPROVE:
+	FailOn                     string            // Minimum severity that makes CheckSelf return an error. Default: "error".
+	GrpcDialOptions            []grpc.DialOption // Options of the GrpcURL client. Default: from GrpcURL and Client*.
+	GrpcURL                    string            // Senzing gRPC server of the engine checks (grpc[s]://host:port).
 	InputURL                   string            // IMPROVE:
 	LicenseStringBase64        string            // IMPROVE:
 	LogLevel                   string            // IMPROVE:
-	ObserverURL                string            // IMPROVE:
+	ObserverOrigin             string            // Identifies this instance in observer messages.
+	ObserverSubjectID          string            // subjectId of observer messages; required to send any.
+	ObserverURL                string            // Observer gRPC server (grpc[s]://host:port). See CheckObserver.
+	OutputFormat               string            // See OutputFormats(). Default: "text".
+	Policy                     string            // PolicyDevelopment or PolicyProduction. Default: PolicyDevelopment.
+	PublishToObserver          bool              // CheckSelf sends the report to ObserverURL. See PublishReport.
 	ResourcePath               string
-	SenzingDirectory           string // IMPROVE:
+	SenzingDirectory           string // Where CheckEngine looks for lib/libSz.so. Default: the library path.
 	SenzingInstanceName        string
+	SelectedChecks             []string // Check IDs or groups to run. Default: all registered checks.
 	SenzingVerboseLogging      int64
 	Settings                   string
+	ShowSecrets                bool     // Report passwords and license strings verbatim. For local debugging only.
+	SkippedChecks              []string // Check IDs or groups not to run.
 	SupportPath                string
+	SzAbstractFactoryCreator   SzAbstractFactoryCreator // Creates the factory of engine checks. Default: by GrpcURL.
+	Timeout                    time.Duration            // Limit for the whole run. Default: none.
+	Writer                     io.Writer                // Destination of rendered output. Default: os.Stdout.
+	checkers                   []Checker
 }
 
+/*
+SzAbstractFactoryCreator creates the senzing.SzAbstractFactory used by an engine
+check (e.g. CheckLicense).  The check closes the factory when it is done.
+See the szfake package for test doubles.
+*/
+type SzAbstractFactoryCreator func(ctx context.Context) (senzing.SzAbstractFactory, error)
+
 type ProductLicenseResponse struct {
 	Billing      string `json:"billing"`
 	Contract     string `json:"contract"`
@@ -68,86 +94,148 @@ var defaultInstanceName = "check-self"
 // ----------------------------------------------------------------------------
 
 /*
-The CheckSelf method prints the output of numerous checks.
+The CheckSelf method runs numerous checks and writes a report in the OutputFormat.
 
 Input
   - ctx: A context to control lifecycle.
 
 Output
-  - Nothing is returned, except for an error.  However, something is printed.
-    See the example output.
+  - Nothing is returned, except for an error if any finding is at or above the
+    FailOn severity, or if PublishToObserver is set and the report could not
+    be published.  However, something is written to the Writer
+    (default: os.Stdout).  See the example output.
 */
 func (checkself *BasicCheckSelf) CheckSelf(ctx context.Context) error {
-	var err error
+	failOn, err := checkself.getFailOn()
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
 
-	reportChecks := []string{}
-	reportInfo := []string{}
-	reportErrors := []string{}
+	renderer, err := checkself.getRenderer()
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
 
-	// List tests.  Order is important.
+	if checkself.PublishToObserver && len(checkself.ObserverURL) == 0 {
+		return wraperror.Errorf(errForPackage, "PublishToObserver: ObserverURL is not set")
+	}
 
-	testFunctions := checkself.getTestFunctions()
+	if checkself.PublishToObserver && len(checkself.ObserverSubjectID) == 0 {
+		return wraperror.Errorf(errForPackage, "PublishToObserver: ObserverSubjectID is not set")
+	}
 
-	// Perform checks.
+	report, err := checkself.Run(ctx)
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
 
-	for _, testFunction := range testFunctions {
-		reportChecks, reportInfo, reportErrors, err = testFunction(ctx, reportChecks, reportInfo, reportErrors)
-		if err != nil {
-			if len(err.Error()) > 0 {
-				reportErrors = append(reportErrors, err.Error())
-			}
+	// Output report.
 
-			break
+	err = renderer(checkself.getWriter(), report)
+	if err != nil {
+		return wraperror.Errorf(err, "Could not write report")
+	}
+
+	if checkself.PublishToObserver {
+		err = checkself.PublishReport(ctx, report)
+		if err != nil {
+			return wraperror.Errorf(err, "Could not publish report")
 		}
 	}
 
-	// Output reports.
+	failureCount := len(report.AtOrAbove(failOn))
+	if failureCount > 0 {
+		err = wraperror.Errorf(errForPackage, "%d findings at or above %s severity detected", failureCount, failOn)
+	}
 
-	if len(reportInfo) > 0 {
-		printTitle("Information")
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
 
-		for _, message := range reportInfo {
-			outputln(message)
-		}
+/*
+The Run method runs numerous checks and returns the results without rendering them.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - A report of the information gathered and the findings of each check.
+    Problems in the environment are findings in the report, not errors.
+    Unless ShowSecrets is set, secrets in the report are masked (see RedactSecrets).
+  - An error if the checks could not be run (e.g. ctx was cancelled, Policy is
+    unknown, or SelectedChecks or SkippedChecks names an unknown check or group).
+    Checks cut short by Timeout are reported as CheckStatusTimedOut, not as an error.
+*/
+func (checkself *BasicCheckSelf) Run(ctx context.Context) (*Report, error) {
+	report := newEmptyReport()
+
+	runCtx := ctx
+
+	if checkself.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		runCtx, cancel = context.WithTimeout(ctx, checkself.Timeout)
+		defer cancel()
 	}
 
-	if len(reportChecks) > 0 {
-		printTitle("Checks performed")
+	err := checkself.checkPolicy()
+	if err != nil {
+		return report, wraperror.Errorf(err, wraperror.NoMessage)
+	}
 
-		for index, message := range reportChecks {
-			outputf("%6d. %s\n", index+1, message)
-		}
+	checkers, err := checkself.selectedCheckers()
+	if err != nil {
+		return report, wraperror.Errorf(err, wraperror.NoMessage)
 	}
 
-	if len(reportErrors) > 0 {
-		printTitle("Errors")
+	// Perform checks concurrently, then assemble the report in registry order.
 
-		for index, message := range reportErrors {
-			outputf("%6d. %s\n\n", index+1, message)
-		}
+	for _, run := range checkself.runCheckers(runCtx, checkers) {
+		report.merge(run.report)
+		report.Results = append(report.Results, run.result)
+	}
 
-		err = wraperror.Errorf(errForPackage, "%d errors detected", len(reportErrors))
-		outputf("Result: %s\n", err.Error())
-	} else {
-		printTitle("Result")
-		outputf("No errors detected.\n")
+	if !checkself.ShowSecrets {
+		report.redact()
 	}
 
-	outputf("%s\n\n\n\n\n", strings.Repeat("-", horizontalRuleLength))
+	if err := ctx.Err(); err != nil {
+		return report, wraperror.Errorf(err, "checks interrupted")
+	}
 
-	return wraperror.Errorf(err, wraperror.NoMessage)
+	return report, nil
 }
 
 // ----------------------------------------------------------------------------
 // Private methods
 // ----------------------------------------------------------------------------
 
+func (checkself *BasicCheckSelf) checkPolicy() error {
+	switch checkself.Policy {
+	case "", PolicyDevelopment, PolicyProduction:
+		return nil
+	default:
+		return wraperror.Errorf(errForPackage, "Policy: unknown policy: %s", checkself.Policy)
+	}
+}
+
 func (checkself *BasicCheckSelf) createSzAbstractFactory(ctx context.Context) (senzing.SzAbstractFactory, error) {
 	var (
 		err    error
 		result senzing.SzAbstractFactory
 	)
 
+	if checkself.SzAbstractFactoryCreator != nil {
+		result, err = checkself.SzAbstractFactoryCreator(ctx)
+
+		return result, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	if len(checkself.GrpcURL) > 0 {
+		result, err = checkself.createGrpcAbstractFactory()
+
+		return result, wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
 	result, err = szfactorycreator.CreateCoreAbstractFactory(
 		checkself.getInstanceName(ctx),
 		checkself.getSettings(ctx),
@@ -158,79 +246,102 @@ func (checkself *BasicCheckSelf) createSzAbstractFactory(ctx context.Context) (s
 	return result, wraperror.Errorf(err, wraperror.NoMessage)
 }
 
-func (checkself *BasicCheckSelf) createSzConfigManager(ctx context.Context) (senzing.SzConfigManager, error) {
-	var (
-		err    error
-		result senzing.SzConfigManager
-	)
-
+/*
+The withSzAbstractFactory method creates an SzAbstractFactory, passes it to use,
+and closes it after use returns.  Objects created by the factory must not
+outlive use; over gRPC, closing the factory closes the connection.  An error
+closing the factory, or destroying an object in the withSz* methods, is
+returned along with the error of use.
+*/
+func (checkself *BasicCheckSelf) withSzAbstractFactory(
+	ctx context.Context,
+	use func(szAbstractFactory senzing.SzAbstractFactory) error,
+) (err error) {
 	szAbstractFactory, err := checkself.createSzAbstractFactory(ctx)
 	if err != nil {
-		return result, wraperror.Errorf(err, "Could not create SzAbstractFactory")
+		return wraperror.Errorf(err, "Could not create SzAbstractFactory")
 	}
 
 	defer func() {
-		err := szAbstractFactory.Close(ctx)
+		err = errors.Join(err, wraperror.Errorf(szAbstractFactory.Close(ctx), "Could not close SzAbstractFactory"))
+	}()
+
+	return use(szAbstractFactory)
+}
+
+func (checkself *BasicCheckSelf) withSzConfigManager(
+	ctx context.Context,
+	use func(szConfigManager senzing.SzConfigManager) error,
+) error {
+	return checkself.withSzAbstractFactory(ctx, func(szAbstractFactory senzing.SzAbstractFactory) (err error) {
+		szConfigManager, err := szAbstractFactory.CreateConfigManager(ctx)
 		if err != nil {
-			panic(err)
+			return wraperror.Errorf(err, "Could not create SzConfigManager")
 		}
-	}()
 
-	result, err = szAbstractFactory.CreateConfigManager(ctx)
+		defer func() {
+			err = errors.Join(err, wraperror.Errorf(szConfigManager.Destroy(ctx), "Could not destroy SzConfigManager"))
+		}()
 
-	return result, wraperror.Errorf(err, "Could not create SzConfigManager")
+		return use(szConfigManager)
+	})
 }
 
-func (checkself *BasicCheckSelf) createSzProduct(ctx context.Context) (senzing.SzProduct, error) {
-	var (
-		err    error
-		result senzing.SzProduct
-	)
+func (checkself *BasicCheckSelf) withSzDiagnostic(
+	ctx context.Context,
+	use func(szDiagnostic senzing.SzDiagnostic) error,
+) error {
+	return checkself.withSzAbstractFactory(ctx, func(szAbstractFactory senzing.SzAbstractFactory) (err error) {
+		szDiagnostic, err := szAbstractFactory.CreateDiagnostic(ctx)
+		if err != nil {
+			return wraperror.Errorf(err, "Could not create SzDiagnostic")
+		}
 
-	szAbstractFactory, err := checkself.createSzAbstractFactory(ctx)
-	if err != nil {
-		return result, wraperror.Errorf(err, "Could not create SzAbstractFactory")
-	}
+		defer func() {
+			err = errors.Join(err, wraperror.Errorf(szDiagnostic.Destroy(ctx), "Could not destroy SzDiagnostic"))
+		}()
 
-	defer func() {
-		err := szAbstractFactory.Close(ctx)
+		return use(szDiagnostic)
+	})
+}
+
+func (checkself *BasicCheckSelf) withSzProduct(
+	ctx context.Context,
+	use func(szProduct senzing.SzProduct) error,
+) error {
+	return checkself.withSzAbstractFactory(ctx, func(szAbstractFactory senzing.SzAbstractFactory) (err error) {
+		szProduct, err := szAbstractFactory.CreateProduct(ctx)
 		if err != nil {
-			panic(err)
+			return wraperror.Errorf(err, "Could not create SzProduct")
 		}
-	}()
 
-	result, err = szAbstractFactory.CreateProduct(ctx)
+		defer func() {
+			err = errors.Join(err, wraperror.Errorf(szProduct.Destroy(ctx), "Could not destroy SzProduct"))
+		}()
 
-	return result, wraperror.Errorf(err, "Could not create SzProduct")
+		return use(szProduct)
+	})
 }
 
 func (checkself *BasicCheckSelf) getDatabaseURL(ctx context.Context) (string, error) {
-	if len(checkself.DatabaseURL) > 0 { // Simple case.
-		return checkself.DatabaseURL, nil
-	}
+	_ = ctx
 
-	if len(checkself.Settings) == 0 {
+	if len(checkself.DatabaseURL) == 0 && len(checkself.Settings) == 0 {
 		return "", wraperror.Errorf(errForPackage, "neither DatabaseUrl nor settings set")
 	}
 
-	// Pull database from Senzing engine configuration json.
-	// IMPROVE: This code only returns one database.  Need to handle the multi-database case.
-
-	parsedSettings, err := settingsparser.New(checkself.Settings)
-	if err != nil {
-		return "", wraperror.Errorf(errForPackage, "unable to parse settings: %s", checkself.Settings)
-	}
-
-	databaseUris, err := parsedSettings.GetDatabaseURIs(ctx)
-	if err != nil {
-		return "", wraperror.Errorf(errForPackage, "unable to extract databases from settings: %s", checkself.Settings)
-	}
-
-	if len(databaseUris) == 0 {
-		return "", wraperror.Errorf(err, "no databases found in settings: %s", checkself.Settings)
+	for _, database := range checkself.getDatabases() {
+		if database.Name == CoreDatabaseName {
+			return database.URL, nil
+		}
 	}
 
-	return databaseUris[0], nil
+	return "", wraperror.Errorf(
+		errForPackage,
+		"no %s database found in settings: %s",
+		CoreDatabaseName,
+		checkself.Settings,
+	)
 }
 
 func (checkself *BasicCheckSelf) getDatabaseConnector(ctx context.Context) (driver.Connector, error) {
@@ -247,7 +358,7 @@ func (checkself *BasicCheckSelf) getDatabaseConnector(ctx context.Context) (driv
 		)
 	}
 
-	result, err = connector.NewConnector(ctx, databaseURL)
+	result, err = newDatabaseConnector(ctx, databaseURL)
 	if err != nil {
 		return result, wraperror.Errorf(
 			err,
@@ -259,6 +370,16 @@ func (checkself *BasicCheckSelf) getDatabaseConnector(ctx context.Context) (driv
 	return result, wraperror.Errorf(err, wraperror.NoMessage)
 }
 
+func (checkself *BasicCheckSelf) getFailOn() (Severity, error) {
+	if len(checkself.FailOn) == 0 {
+		return SeverityError, nil
+	}
+
+	result, err := ParseSeverity(checkself.FailOn)
+
+	return result, wraperror.Errorf(err, "FailOn")
+}
+
 func (checkself *BasicCheckSelf) getInstanceName(ctx context.Context) string {
 	_ = ctx
 
@@ -270,6 +391,24 @@ func (checkself *BasicCheckSelf) getInstanceName(ctx context.Context) string {
 	return result
 }
 
+func (checkself *BasicCheckSelf) getRenderer() (Renderer, error) {
+	if len(checkself.OutputFormat) == 0 {
+		return RenderText, nil
+	}
+
+	result, err := GetRenderer(checkself.OutputFormat)
+
+	return result, wraperror.Errorf(err, "OutputFormat")
+}
+
+func (checkself *BasicCheckSelf) getWriter() io.Writer {
+	if checkself.Writer == nil {
+		return os.Stdout
+	}
+
+	return checkself.Writer
+}
+
 func (checkself *BasicCheckSelf) getSettings(ctx context.Context) string {
 	_ = ctx
 
@@ -286,57 +425,19 @@ func (checkself *BasicCheckSelf) getSettings(ctx context.Context) string {
 	return result
 }
 
-func (checkself *BasicCheckSelf) getTestFunctions() []func(ctx context.Context, reportChecks []string, reportInfo []string, reportErrors []string) ([]string, []string, []string, error) {
-	return []func(ctx context.Context, reportChecks []string, reportInfo []string, reportErrors []string) ([]string, []string, []string, error){
-		checkself.Prolog,
-		checkself.ListEnvironmentVariables,
-		checkself.ListStructVariables,
-		checkself.CheckConfigPath,
-		checkself.CheckResourcePath,
-		checkself.CheckSupportPath,
-		checkself.CheckDatabaseURL,
-		checkself.CheckSettings,
-		checkself.Break,
-		checkself.CheckDatabaseSchema,
-		checkself.Break,
-		// checkself.CheckSenzingConfiguration,
-		// checkself.CheckLicense,
-	}
-}
-
 // ----------------------------------------------------------------------------
 // Private functions
 // ----------------------------------------------------------------------------
 
-func statFiles(variableName string, path string, requiredFiles []string) []string {
-	reportErrors := []string{}
+func statFiles(variableName string, path string, requiredFiles []string) []Finding {
+	findings := []Finding{}
 
 	for _, requiredFile := range requiredFiles {
 		targetFile := fmt.Sprintf("%s/%s", path, requiredFile)
 		if _, err := os.Stat(targetFile); err != nil {
-			reportErrors = append(
-				reportErrors,
-				fmt.Sprintf(
-					"%s = %s is misconfigured. Could not find %s. For more information, visit https://hub.senzing.com/...",
-					variableName,
-					path,
-					targetFile,
-				),
-			)
+			findings = append(findings, newError(variableName, path, "Could not find "+targetFile+".", nil))
 		}
 	}
 
-	return reportErrors
-}
-
-func outputf(format string, message ...any) {
-	fmt.Printf(format, message...) //nolint
-}
-
-func outputln(message ...any) {
-	fmt.Println(message...) //nolint
-}
-
-func printTitle(title string) {
-	outputf("\n-- %s %s\n\n", title, strings.Repeat("-", horizontalTitleLength-len(title)))
+	return findings
 }
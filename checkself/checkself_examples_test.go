@@ -5,6 +5,7 @@ package checkself_test
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/senzing-garage/check-self/checkself"
 )
@@ -23,3 +24,126 @@ func ExampleBasicCheckSelf_CheckSelf() {
 		fmt.Print(err)
 	}
 }
+
+func ExampleBasicCheckSelf_Run() {
+	// For more information, visit https://github.com/senzing-garage/check-self/blob/main/checkself/checkself_examples_test.go
+	ctx := context.TODO()
+	examplePackage := &checkself.BasicCheckSelf{}
+
+	report, err := examplePackage.Run(ctx)
+	if err != nil {
+		fmt.Print(err)
+	}
+
+	for _, finding := range report.Errors() {
+		fmt.Println(finding.CheckID, finding.Subject, finding.Message)
+	}
+}
+
+func ExampleRenderJSON() {
+	// For more information, visit https://github.com/senzing-garage/check-self/blob/main/checkself/checkself_examples_test.go
+	report := &checkself.Report{}
+	report.AddCheck("Check database URL")
+	report.AddFinding(checkself.Finding{
+		CheckID:  checkself.CheckIDDatabaseURL,
+		Message:  "Could not connect.",
+		Severity: checkself.SeverityCritical,
+	})
+
+	err := checkself.RenderJSON(os.Stdout, report)
+	if err != nil {
+		fmt.Print(err)
+	}
+	// Output:
+	// {
+	//   "checks": [
+	//     "Check database URL"
+	//   ],
+	//   "findings": [
+	//     {
+	//       "checkId": "database-url",
+	//       "message": "Could not connect.",
+	//       "severity": "CRITICAL"
+	//     }
+	//   ],
+	//   "info": [],
+	//   "results": [],
+	//   "schemaVersion": "1",
+	//   "statistics": [],
+	//   "status": "FAILED",
+	//   "summary": {
+	//     "CRITICAL": 1,
+	//     "ERROR": 0,
+	//     "INFO": 0,
+	//     "WARNING": 0
+	//   }
+	// }
+}
+
+func ExampleRenderJUnit() {
+	// For more information, visit https://github.com/senzing-garage/check-self/blob/main/checkself/checkself_examples_test.go
+	err := checkself.RenderJUnit(os.Stdout, getExampleReport())
+	if err != nil {
+		fmt.Print(err)
+	}
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <testsuites failures="1" name="check-self" skipped="1" tests="3" time="0.000">
+	//   <testsuite failures="1" name="check-self" skipped="1" tests="3" time="0.000">
+	//     <testcase classname="check-self" name="settings" time="0.000"></testcase>
+	//     <testcase classname="check-self" name="database-url" time="0.000">
+	//       <failure message="Could not connect. For more information, visit https://hub.senzing.com/..." type="CRITICAL">CRITICAL: Could not connect. For more information, visit https://hub.senzing.com/...</failure>
+	//     </testcase>
+	//     <testcase classname="check-self" name="database-schema" time="0.000">
+	//       <skipped message="dependency database-url failed"></skipped>
+	//     </testcase>
+	//   </testsuite>
+	// </testsuites>
+}
+
+func ExampleRenderTAP() {
+	// For more information, visit https://github.com/senzing-garage/check-self/blob/main/checkself/checkself_examples_test.go
+	err := checkself.RenderTAP(os.Stdout, getExampleReport())
+	if err != nil {
+		fmt.Print(err)
+	}
+	// Output:
+	// TAP version 14
+	// 1..3
+	// ok 1 - settings
+	// not ok 2 - database-url
+	//   ---
+	//   status: "FAILED"
+	//   durationMs: 0
+	//   findings:
+	//     - severity: CRITICAL
+	//       message: "Could not connect. For more information, visit https://hub.senzing.com/..."
+	//   ...
+	// ok 3 - database-schema # SKIP dependency database-url failed
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func getExampleReport() *checkself.Report {
+	return &checkself.Report{
+		Findings: []checkself.Finding{
+			{
+				CheckID:        checkself.CheckIDDatabaseURL,
+				Message:        "Could not connect.",
+				RemediationURL: "https://hub.senzing.com/...",
+				Severity:       checkself.SeverityCritical,
+			},
+		},
+		Results: []checkself.CheckResult{
+			{CheckID: checkself.CheckIDSettings, Status: checkself.CheckStatusPassed},
+			{CheckID: checkself.CheckIDDatabaseURL, Status: checkself.CheckStatusFailed},
+			{
+				CheckID: checkself.CheckIDDatabaseSchema,
+				Reason:  "dependency database-url failed",
+				Status:  checkself.CheckStatusSkipped,
+			},
+		},
+	}
+}
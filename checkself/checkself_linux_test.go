@@ -3,6 +3,9 @@
 package checkself_test
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/senzing-garage/check-self/checkself"
@@ -32,27 +35,130 @@ func TestBasicCheckSelf_CheckSelf_Paths(test *testing.T) {
 func TestBasicCheckSelf_CheckDatabaseSchema_noSchemaInstalled(test *testing.T) {
 	test.Parallel()
 	ctx := test.Context()
-	expected := `Senzing database schema has not been installed in sqlite3://na:na@/tmp/sqlite/G2C-empty.db. For more information, visit https://hub.senzing.com/...  Error: {"function": "checker.(*BasicChecker).IsSchemaInstalled", "text": "row.Scan", "error": "no such table: DSRC_RECORD"}`
+	expected := `[CORE] Senzing database schema has not been installed in sqlite3://na:na@/tmp/sqlite/G2C-empty.db. To install it, run "check-self fix schema". For more information, visit https://hub.senzing.com/...  Error: {"function": "checker.(*BasicChecker).IsSchemaInstalled", "text": "row.Scan", "error": "no such table: DSRC_RECORD"}`
 	testObject := getTestObject(ctx, test)
-	badReportErrors := []string{}
 	testObject.DatabaseURL = "sqlite3://na:na@/tmp/sqlite/G2C-empty.db"
-	newReportChecks, newReportInfo, newReportErrors, err := testObject.CheckDatabaseSchema(
-		ctx,
-		reportChecks(),
-		reportInfo(),
-		badReportErrors,
+	report := newReport()
+	err := testObject.CheckDatabaseSchema(ctx, report)
+	require.NoError(test, err)
+	assert.Len(test, report.Checks, 1)
+	assert.Empty(test, report.Info)
+	assert.Len(test, report.Findings, 1)
+	assert.Equal(test, expected, report.Findings[0].String())
+}
+
+func TestBasicCheckSelf_CheckDatabaseSchema_hybrid(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(ctx, test)
+	testObject.Settings = hybridSettings
+	report := newReport()
+	err := testObject.CheckDatabaseSchema(ctx, report)
+	require.NoError(test, err)
+	assert.Len(test, report.Checks, 3)
+	assert.Len(test, report.Info, 2)
+	require.Len(test, report.Findings, 1)
+	assert.Equal(test, "LIBFEAT", report.Findings[0].Database)
+	assert.Equal(test, "SENZING_TOOLS_CORE_SETTINGS.LIBFEAT.DB_1", report.Findings[0].Subject)
+}
+
+func TestBasicCheckSelf_CheckDatabaseURL_hybrid(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(ctx, test)
+	testObject.Settings = hybridSettings
+	testObject.DatabaseURL = badDatabaseURL
+	report := newReport()
+	err := testObject.CheckDatabaseURL(ctx, report)
+	require.NoError(test, err)
+	assert.Len(test, report.Checks, 3)
+	require.Len(test, report.Errors(), 1)
+	assert.Equal(test, checkself.CoreDatabaseName, report.Errors()[0].Database)
+	assert.Equal(test, "SENZING_TOOLS_DATABASE_URL", report.Errors()[0].Subject)
+	assert.Len(test, report.Observations(), 2)
+}
+
+func TestBasicCheckSelf_CheckEngine_notLoadable(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(ctx, test)
+	library := readTestLibrary(test)
+	require.True(test, bytes.Contains(library, []byte("libc.so.6\x00")))
+	library = bytes.Replace(library, []byte("libc.so.6\x00"), []byte("libX.so.6\x00"), 1)
+	testObject.SenzingDirectory = newTestSenzingDirectory(test, library, "4.0.0")
+	report := newReport()
+	err := testObject.CheckEngine(ctx, report)
+	require.NoError(test, err)
+	require.Len(test, report.Errors(), 1)
+	require.Equal(
+		test,
+		"Engine checks skipped: "+testObject.SenzingDirectory+"/lib/libSz.so could not be loaded.",
+		report.Errors()[0].Message,
 	)
+	require.ErrorContains(test, report.Errors()[0].Err, "libX.so.6")
+}
+
+func TestBasicCheckSelf_CheckEngine_notSharedLibrary(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(ctx, test)
+	testObject.SenzingDirectory = newTestSenzingDirectory(test, []byte("not a library"), "4.0.0")
+	report := newReport()
+	err := testObject.CheckEngine(ctx, report)
+	require.NoError(test, err)
+	require.Len(test, report.Errors(), 1)
+	require.Equal(
+		test,
+		"Engine checks skipped: "+testObject.SenzingDirectory+"/lib/libSz.so is not a shared library built for this architecture.",
+		report.Errors()[0].Message,
+	)
+}
+
+func TestBasicCheckSelf_CheckEngine_noVersion(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(ctx, test)
+	testObject.SenzingDirectory = newTestSenzingDirectory(test, readTestLibrary(test), "")
+	report := newReport()
+	err := testObject.CheckEngine(ctx, report)
 	require.NoError(test, err)
-	assert.Len(test, newReportChecks, 1)
-	assert.Empty(test, newReportInfo)
-	assert.Len(test, newReportErrors, 1)
-	assert.Equal(test, expected, newReportErrors[0])
+	require.Empty(test, report.Errors())
+	require.Len(test, report.Warnings(), 1)
+	require.Contains(test, report.Warnings()[0].Message, "Could not read the Senzing version from ")
+}
+
+func TestBasicCheckSelf_CheckEngine_systemLibraryDirectories(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(ctx, test)
+	report := newReport()
+	err := testObject.CheckEngine(ctx, report)
+	require.NoError(test, err)
+	require.Len(test, report.Checks, 1)
+	require.Regexp(test, `:/usr/lib(:|$)`, report.Checks[0])
+}
+
+func TestBasicCheckSelf_CheckEngine_wrongVersion(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(ctx, test)
+	testObject.SenzingDirectory = newTestSenzingDirectory(test, readTestLibrary(test), "3.12.0")
+	report := newReport()
+	err := testObject.CheckEngine(ctx, report)
+	require.NoError(test, err)
+	require.Len(test, report.Errors(), 1)
+	require.Equal(
+		test,
+		"Engine checks skipped: "+testObject.SenzingDirectory+
+			"/lib/libSz.so is Senzing 3.12.0, but check-self expects Senzing 4.x.",
+		report.Errors()[0].Message,
+	)
 }
 
 func TestBasicCheckSelf_CheckLicense_badGetLicense(test *testing.T) {
 	test.Parallel()
 	ctx := test.Context()
-	expected := `getRecordCount: {"function": "checkself.(*BasicCheckSelf).getRecordCount", "text": "Could not get count of records.", "error": {"function": "checker.(*BasicChecker).RecordCount", "text": "row.Scan", "error": "no such table: DSRC_RECORD"}}`
+	expected := `Could not get record count. For more information, visit https://hub.senzing.com/...  Error: {"function": "checkself.(*BasicCheckSelf).getRecordCount", "text": "Could not get count of records.", "error": {"function": "checker.(*BasicChecker).RecordCount", "text": "row.Scan", "error": "no such table: DSRC_RECORD"}}`
 	testObject := getTestObject(ctx, test)
 	testObject.Settings = `
         {
@@ -68,23 +174,19 @@ func TestBasicCheckSelf_CheckLicense_badGetLicense(test *testing.T) {
             }
         }
         `
-	newReportChecks, newReportInfo, newReportErrors, err := testObject.CheckLicense(
-		ctx,
-		reportChecks(),
-		reportInfo(),
-		reportErrors(),
-	)
+	report := newReport()
+	err := testObject.CheckLicense(ctx, report)
 	require.NoError(test, err)
-	assert.Len(test, newReportChecks, 1)
-	assert.Empty(test, newReportInfo)
-	assert.Len(test, newReportErrors, 1)
-	assert.Equal(test, expected, newReportErrors[0])
+	assert.Len(test, report.Checks, 1)
+	assert.Empty(test, report.Info)
+	assert.Len(test, report.Findings, 1)
+	assert.Equal(test, expected, report.Findings[0].String())
 }
 
-func TestBasicCheckSelf_CheckSettings_badDatabaseURLs(test *testing.T) {
+func TestBasicCheckSelf_CheckDatabaseURL_noConnection(test *testing.T) {
 	test.Parallel()
 	ctx := test.Context()
-	// expected := "????"
+	expected := "SENZING_TOOLS_CORE_SETTINGS.SQL.CONNECTION is not set. For more information, visit https://hub.senzing.com/..."
 	testObject := getTestObject(ctx, test)
 	testObject.Settings = `
         {
@@ -99,17 +201,13 @@ func TestBasicCheckSelf_CheckSettings_badDatabaseURLs(test *testing.T) {
             }
         }
         `
-	newReportChecks, newReportInfo, newReportErrors, err := testObject.CheckSettings(
-		ctx,
-		reportChecks(),
-		reportInfo(),
-		reportErrors(),
-	)
+	report := newReport()
+	err := testObject.CheckDatabaseURL(ctx, report)
 	require.NoError(test, err)
-	assert.Len(test, newReportChecks, 1)
-	assert.Empty(test, newReportInfo)
-	// assert.Equal(test, expected, newReportErrors[0])
-	assert.Len(test, newReportErrors, 2)
+	assert.Len(test, report.Checks, 1)
+	assert.Empty(test, report.Info)
+	assert.Len(test, report.Findings, 1)
+	assert.Equal(test, expected, report.Findings[0].String())
 }
 
 func TestBasicCheckSelf_CheckDatabaseURL_badSqliteURL_stat(test *testing.T) {
@@ -118,5 +216,42 @@ func TestBasicCheckSelf_CheckDatabaseURL_badSqliteURL_stat(test *testing.T) {
 	expected := "VariableName = sqlite3://na:na@/tmp/nodatabase.db is misconfigured. Could not find /tmp/nodatabase.db. For more information, visit https://hub.senzing.com/..."
 	badDatabaseURL := "sqlite3://na:na@/tmp/nodatabase.db"
 	actual := checkself.CheckDatabaseURL(ctx, variableName, badDatabaseURL)
-	assert.Equal(test, expected, actual[0])
+	assert.Equal(test, expected, actual[0].String())
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+// newTestSenzingDirectory creates a Senzing directory holding lib/libSz.so and, if version is set, szBuildVersion.json.
+func newTestSenzingDirectory(t *testing.T, library []byte, version string) string {
+	t.Helper()
+
+	result := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(result, "lib"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(result, "lib", "libSz.so"), library, 0o600))
+
+	if len(version) > 0 {
+		buildVersion := `{"BUILD_VERSION": "` + version + `.0", "VERSION": "` + version + `"}`
+		require.NoError(t, os.WriteFile(filepath.Join(result, "szBuildVersion.json"), []byte(buildVersion), 0o600))
+	}
+
+	return result
+}
+
+// readTestLibrary returns the contents of the libSz.so the engine checks would load.
+func readTestLibrary(t *testing.T) []byte {
+	t.Helper()
+
+	searchPath := append(filepath.SplitList(os.Getenv("LD_LIBRARY_PATH")), "/opt/senzing/er/lib")
+	for _, directory := range searchPath {
+		result, err := os.ReadFile(filepath.Join(directory, "libSz.so"))
+		if err == nil {
+			return result
+		}
+	}
+
+	t.Skip("libSz.so not found")
+
+	return nil
 }
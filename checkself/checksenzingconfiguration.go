@@ -2,56 +2,61 @@ package checkself
 
 import (
 	"context"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
 )
 
+// CheckIDSenzingConfiguration identifies the CheckSenzingConfiguration check.
+const CheckIDSenzingConfiguration = "senzing-configuration"
+
 // ----------------------------------------------------------------------------
 // Interface methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) CheckSenzingConfiguration(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
-	reportChecks = append(reportChecks, "Check Senzing configuration")
+func (checkself *BasicCheckSelf) CheckSenzingConfiguration(ctx context.Context, report *Report) error {
+	report.AddCheck("Check Senzing configuration")
 
-	// Create Senzing objects.
+	engineMutex.Lock()
+	defer engineMutex.Unlock()
 
-	szConfigManager, err := checkself.createSzConfigManager(ctx)
-	if err != nil {
-		reportErrors = append(reportErrors, "Could not create szConfigManager.  Error: "+err.Error())
+	// Determine if Configuration exists.
 
-		return reportChecks, reportInfo, reportErrors, nil
-	}
+	var configID int64
 
-	defer func() {
-		err := szConfigManager.Destroy(ctx)
-		if err != nil {
-			panic(err)
-		}
-	}()
+	isCreated := false
 
-	// Determine if Configuration exists.
+	err := checkself.withSzConfigManager(ctx, func(szConfigManager senzing.SzConfigManager) error {
+		var err error
+
+		isCreated = true
+		configID, err = szConfigManager.GetDefaultConfigID(ctx)
+
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	})
+
+	switch {
+	case err != nil && !isCreated:
+		report.addFindings(CheckIDSenzingConfiguration, newError("", "", "Could not create szConfigManager.", err))
 
-	configID, err := szConfigManager.GetDefaultConfigID(ctx)
-	if err != nil {
-		reportErrors = append(
-			reportErrors,
-			"Could not get Senzing default configuration ID.  Error "+err.Error(),
+		return nil
+	case err != nil:
+		report.addFindings(
+			CheckIDSenzingConfiguration,
+			newError("", "", "Could not get Senzing default configuration ID.", err),
 		)
 
-		return reportChecks, reportInfo, reportErrors, nil
+		return nil
 	}
 
 	if configID == 0 {
-		reportErrors = append(
-			reportErrors,
-			"Senzing configuration doesn't exist. For more information, visit https://hub.senzing.com/...",
+		report.addFindings(
+			CheckIDSenzingConfiguration,
+			newError("", "", "Senzing configuration doesn't exist.", nil),
 		)
 	}
 
 	// Epilog.
 
-	return reportChecks, reportInfo, reportErrors, nil
+	return nil
 }
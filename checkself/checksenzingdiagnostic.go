@@ -0,0 +1,91 @@
+package checkself
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// repositoryInfo is the response of SzDiagnostic.GetRepositoryInfo.
+type repositoryInfo struct {
+	DataStores []struct {
+		ID       string `json:"id"`
+		Location string `json:"location"`
+		Type     string `json:"type"`
+	} `json:"dataStores"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CheckIDSenzingDiagnostic identifies the CheckSenzingDiagnostic check.
+const CheckIDSenzingDiagnostic = "senzing-diagnostic"
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckSenzingDiagnostic method asks the Senzing engine, native or over gRPC,
+which data stores it uses.  Over gRPC, these are the databases of the server,
+which may differ from the ones check-self is configured with.
+*/
+func (checkself *BasicCheckSelf) CheckSenzingDiagnostic(ctx context.Context, report *Report) error {
+	report.AddCheck("Check Senzing diagnostic repository information")
+
+	engineMutex.Lock()
+	defer engineMutex.Unlock()
+
+	var repositoryInfoJSON string
+
+	err := checkself.withSzDiagnostic(ctx, func(szDiagnostic senzing.SzDiagnostic) error {
+		var err error
+
+		repositoryInfoJSON, err = szDiagnostic.GetRepositoryInfo(ctx)
+
+		return wraperror.Errorf(err, "Could not get repository information")
+	})
+	if err != nil {
+		report.addFindings(
+			CheckIDSenzingDiagnostic,
+			newError("", "", "Could not get Senzing repository information.", err),
+		)
+
+		return nil
+	}
+
+	var info repositoryInfo
+
+	err = json.Unmarshal([]byte(repositoryInfoJSON), &info)
+	if err != nil {
+		report.addFindings(
+			CheckIDSenzingDiagnostic,
+			newError("", "", "Could not parse Senzing repository information.", err),
+		)
+
+		return nil
+	}
+
+	if len(info.DataStores) == 0 {
+		report.addFindings(CheckIDSenzingDiagnostic, newError("", "", "Senzing reports no data stores.", nil))
+	}
+
+	for _, dataStore := range info.DataStores {
+		report.addFindings(CheckIDSenzingDiagnostic, newInfo("", fmt.Sprintf(
+			"Senzing data store %s: %s at %s.",
+			dataStore.ID,
+			dataStore.Type,
+			dataStore.Location,
+		)))
+	}
+
+	return nil
+}
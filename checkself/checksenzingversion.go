@@ -0,0 +1,68 @@
+package checkself
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// CheckIDSenzingVersion identifies the CheckSenzingVersion check.
+const CheckIDSenzingVersion = "senzing-version"
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The CheckSenzingVersion method asks the Senzing engine, native or over gRPC,
+for its version and compares it with ExpectedSenzingMajorVersion.
+*/
+func (checkself *BasicCheckSelf) CheckSenzingVersion(ctx context.Context, report *Report) error {
+	report.AddCheck("Check Senzing version")
+
+	engineMutex.Lock()
+	defer engineMutex.Unlock()
+
+	var versionJSON string
+
+	err := checkself.withSzProduct(ctx, func(szProduct senzing.SzProduct) error {
+		var err error
+
+		versionJSON, err = szProduct.GetVersion(ctx)
+
+		return wraperror.Errorf(err, "Could not get version information")
+	})
+	if err != nil {
+		report.addFindings(CheckIDSenzingVersion, newError("", "", "Could not get Senzing version.", err))
+
+		return nil
+	}
+
+	version, err := parseBuildVersion([]byte(versionJSON), "SzProduct.GetVersion")
+	if err != nil {
+		report.addFindings(CheckIDSenzingVersion, newError("", "", "Could not parse Senzing version.", err))
+
+		return nil
+	}
+
+	majorVersion, _, _ := strings.Cut(version.Version, ".")
+	if majorVersion != ExpectedSenzingMajorVersion {
+		report.addFindings(CheckIDSenzingVersion, newError("", "", fmt.Sprintf(
+			"Senzing engine is version %s, but check-self expects Senzing %s.x.",
+			version.Version,
+			ExpectedSenzingMajorVersion,
+		), nil))
+
+		return nil
+	}
+
+	report.addFindings(CheckIDSenzingVersion, newInfo(
+		"",
+		fmt.Sprintf("Senzing version: %s (build %s).", version.Version, version.BuildVersion),
+	))
+
+	return nil
+}
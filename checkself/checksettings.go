@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"strings"
 
 	"github.com/senzing-garage/go-cmdhelping/option"
@@ -12,18 +11,22 @@ import (
 	"github.com/senzing-garage/go-helpers/settingsparser"
 )
 
+// CheckIDSettings identifies the CheckSettings check.
+const CheckIDSettings = "settings"
+
 // ----------------------------------------------------------------------------
 // Interface methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) CheckSettings(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
+func (checkself *BasicCheckSelf) CheckSettings(ctx context.Context, report *Report) error {
+	if len(checkself.Settings) == 0 && len(checkself.DatabaseURL) == 0 && len(checkself.GrpcURL) > 0 {
+		report.AddCheck("Check engine configuration: not used; GrpcURL is set")
+
+		return nil
+	}
+
 	if len(checkself.Settings) == 0 { // Short-circuit exit.
-		return checkself.buildAndCheckSettings(ctx, reportChecks, reportInfo, reportErrors)
+		return checkself.buildAndCheckSettings(ctx, report)
 	}
 
 	// Verify that JSON string is syntactically correct.
@@ -31,87 +34,64 @@ func (checkself *BasicCheckSelf) CheckSettings(
 	parsedSettings, err := settingsparser.New(checkself.Settings)
 	if err != nil {
 		normalizedValue := strings.ReplaceAll(strings.ReplaceAll(checkself.Settings, "\n", " "), "  ", "")
-		reportChecks = append(reportChecks, fmt.Sprintf("%s = %s", option.CoreSettings.Envar, normalizedValue))
-		reportErrors = append(reportErrors, fmt.Sprintf("%s - %s", option.CoreSettings.Envar, err.Error()))
-
-		return reportChecks, reportInfo, reportErrors, nil
-	}
-
-	databaseURIs, err := parsedSettings.GetDatabaseURIs(ctx)
-	if err != nil {
-		reportErrors = append(reportErrors, err.Error())
-
-		return reportChecks, reportInfo, reportErrors, nil
-	}
+		report.AddCheck("%s = %s", option.CoreSettings.Envar, normalizedValue)
+		report.addFindings(
+			CheckIDSettings,
+			newCritical(option.CoreSettings.Envar, normalizedValue, "Could not parse JSON.", err),
+		)
 
-	for _, databaseURI := range databaseURIs {
-		errorList := CheckDatabaseURL(ctx, option.CoreSettings.Envar, databaseURI)
-		reportErrors = append(reportErrors, errorList...)
+		return nil
 	}
 
 	// Report what is being checked.
 
 	redactedJSON, err := parsedSettings.RedactedJSON(ctx)
 	if err != nil {
-		reportErrors = append(reportErrors, err.Error())
+		report.addFindings(
+			CheckIDSettings,
+			newError(option.CoreSettings.Envar, "", "Could not redact JSON.", err),
+		)
 
-		return reportChecks, reportInfo, reportErrors, nil
+		return nil
 	}
 
-	reportChecks = append(
-		reportChecks,
-		fmt.Sprintf("Check engine configuration: %s = %s", option.CoreSettings.Envar, redactedJSON),
-	)
+	report.AddCheck("Check engine configuration: %s = %s", option.CoreSettings.Envar, redactedJSON)
 
 	// Perform check.
 
-	return checkself.checkSettings(ctx, checkself.Settings, reportChecks, reportInfo, reportErrors)
+	return checkself.checkSettings(ctx, checkself.Settings, report)
 }
 
 // ----------------------------------------------------------------------------
 // Helper methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) buildAndCheckSettings(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
+func (checkself *BasicCheckSelf) buildAndCheckSettings(ctx context.Context, report *Report) error {
 	settings, err := settings.BuildSimpleSettingsUsingEnvVars()
 	if err != nil {
-		reportErrors = append(reportErrors, "Could not build engine configuration json. "+err.Error())
+		report.addFindings(CheckIDSettings, newError("", "", "Could not build engine configuration json.", err))
 
-		return reportChecks, reportInfo, reportErrors, nil //nolint
+		return nil
 	}
 
 	var prettyJSON bytes.Buffer
 
 	err = json.Indent(&prettyJSON, []byte(settings), "", "\t")
 	if err != nil {
-		reportErrors = append(reportErrors, "Could not parse license information.  Error: "+err.Error())
+		report.addFindings(CheckIDSettings, newError("", "", "Could not parse engine configuration json.", err))
 
-		return reportChecks, reportInfo, reportErrors, nil //nolint
+		return nil
 	}
 
-	reportInfo = append(
-		reportInfo,
-		fmt.Sprintf(
-			"\nEffective engine configuration:\n\nexport SENZING_TOOLS_ENGINE_CONFIGURATION_JSON='%s'\n",
-			prettyJSON.String(),
-		),
+	report.AddInfo(
+		"\nEffective engine configuration:\n\nexport SENZING_TOOLS_ENGINE_CONFIGURATION_JSON='%s'\n",
+		prettyJSON.String(),
 	)
 
-	return checkself.checkSettings(ctx, settings, reportChecks, reportInfo, reportErrors)
+	return checkself.checkSettings(ctx, settings, report)
 }
 
-func (checkself *BasicCheckSelf) checkSettings(
-	ctx context.Context,
-	settings string,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
+func (checkself *BasicCheckSelf) checkSettings(ctx context.Context, settings string, report *Report) error {
 	parsedSettings := &settingsparser.BasicSettingsParser{
 		Settings: settings,
 	}
@@ -122,12 +102,12 @@ func (checkself *BasicCheckSelf) checkSettings(
 
 	configValue, err := parsedSettings.GetConfigPath(ctx)
 	if err != nil {
-		reportErrors = append(reportErrors, fmt.Sprintf("Could not parse %s. Error: %s", configVariable, err.Error()))
+		report.addFindings(CheckIDSettings, newError(configVariable, "", "Could not parse "+configVariable+".", err))
 
-		return reportChecks, reportInfo, reportErrors, nil
+		return nil
 	}
 
-	reportErrors = append(reportErrors, statFiles(configVariable, configValue, RequiredConfigFiles)...)
+	report.addFindings(CheckIDSettings, statFiles(configVariable, configValue, RequiredConfigFiles)...)
 
 	// Test SENZING_TOOLS_ENGINE_CONFIGURATION_JSON.PIPELINE.RESOURCEPATH.
 
@@ -135,12 +115,15 @@ func (checkself *BasicCheckSelf) checkSettings(
 
 	resourceValue, err := parsedSettings.GetResourcePath(ctx)
 	if err != nil {
-		reportErrors = append(reportErrors, fmt.Sprintf("Could not parse %s. Error: %s", resourceVariable, err.Error()))
+		report.addFindings(
+			CheckIDSettings,
+			newError(resourceVariable, "", "Could not parse "+resourceVariable+".", err),
+		)
 
-		return reportChecks, reportInfo, reportErrors, nil
+		return nil
 	}
 
-	reportErrors = append(reportErrors, statFiles(resourceVariable, resourceValue, RequiredResourceFiles)...)
+	report.addFindings(CheckIDSettings, statFiles(resourceVariable, resourceValue, RequiredResourceFiles)...)
 
 	// Test SENZING_TOOLS_ENGINE_CONFIGURATION_JSON.PIPELINE.SUPPORTPATH.
 
@@ -148,35 +131,15 @@ func (checkself *BasicCheckSelf) checkSettings(
 
 	supportValue, err := parsedSettings.GetSupportPath(ctx)
 	if err != nil {
-		reportErrors = append(reportErrors, fmt.Sprintf("Could not parse %s. Error: %s", supportVariable, err.Error()))
-
-		return reportChecks, reportInfo, reportErrors, nil
-	}
-
-	reportErrors = append(reportErrors, statFiles(supportVariable, supportValue, RequiredSupportFiles)...)
-	reportErrors = append(reportErrors, checkDatabaseURIs(ctx, parsedSettings)...)
-
-	return reportChecks, reportInfo, reportErrors, nil
-}
-
-func checkDatabaseURIs(ctx context.Context, parsedSettings *settingsparser.BasicSettingsParser) []string {
-	var result []string
-
-	connectionVariable := "SENZING_TOOLS_ENGINE_CONFIGURATION_JSON.SQL.CONNECTION"
-
-	databaseURIs, err := parsedSettings.GetDatabaseURIs(ctx)
-	if err != nil {
-		result = append(
-			result,
-			fmt.Sprintf("Could not parse %s. Error: %s", connectionVariable, err.Error()),
+		report.addFindings(
+			CheckIDSettings,
+			newError(supportVariable, "", "Could not parse "+supportVariable+".", err),
 		)
 
-		return result
+		return nil
 	}
 
-	for _, databaseURI := range databaseURIs {
-		result = append(result, CheckDatabaseURL(ctx, connectionVariable, databaseURI)...)
-	}
+	report.addFindings(CheckIDSettings, statFiles(supportVariable, supportValue, RequiredSupportFiles)...)
 
-	return result
+	return nil
 }
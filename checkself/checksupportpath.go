@@ -2,11 +2,13 @@ package checkself
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/senzing-garage/go-cmdhelping/option"
 )
 
+// CheckIDSupportPath identifies the CheckSupportPath check.
+const CheckIDSupportPath = "support-path"
+
 var RequiredSupportFiles = []string{
 	"g2SifterRules.ibm",
 }
@@ -15,33 +17,25 @@ var RequiredSupportFiles = []string{
 // Interface methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) CheckSupportPath(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
+func (checkself *BasicCheckSelf) CheckSupportPath(ctx context.Context, report *Report) error {
 	_ = ctx
 
 	// Short-circuit exit.
 
 	if len(checkself.SupportPath) == 0 {
-		return reportChecks, reportInfo, reportErrors, nil
+		return nil
 	}
 
 	// Prolog.
 
-	reportChecks = append(
-		reportChecks,
-		fmt.Sprintf("Check support path: %s = %s", option.SupportPath.Envar, checkself.SupportPath),
-	)
+	report.AddCheck("Check support path: %s = %s", option.SupportPath.Envar, checkself.SupportPath)
 
 	// Check Resource path.
 
-	errorList := statFiles(option.SupportPath.Envar, checkself.SupportPath, RequiredSupportFiles)
-	reportErrors = append(reportErrors, errorList...)
+	findings := statFiles(option.SupportPath.Envar, checkself.SupportPath, RequiredSupportFiles)
+	report.addFindings(CheckIDSupportPath, findings...)
 
 	// Epilog.
 
-	return reportChecks, reportInfo, reportErrors, nil
+	return nil
 }
@@ -0,0 +1,165 @@
+package checkself
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Database is a database used by the Senzing engine.
+type Database struct {
+	Name     string // Logical name: "CORE", or the HYBRID cluster key (e.g. "RES", "LIBFEAT").
+	URL      string // Database URL.
+	Variable string // Variable the URL came from (e.g. SENZING_TOOLS_DATABASE_URL).
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// CoreDatabaseName is the logical name of the database in SQL.CONNECTION.
+const CoreDatabaseName = "CORE"
+
+const (
+	settingsBackendSQL = "SQL"
+	settingsClusterURL = "DB_1"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The ParseDatabases function lists the databases in Senzing engine settings.
+
+The database in SQL.CONNECTION is named "CORE".  When SQL.BACKEND names a
+HYBRID-style section, each cluster it refers to is named by its key and its
+URL is the cluster's DB_1 value.
+
+Input
+  - settings: Senzing engine settings JSON.
+
+Output
+  - The databases, CORE first and clusters sorted by name.
+*/
+func ParseDatabases(settings string) ([]Database, error) {
+	var (
+		dictionary map[string]json.RawMessage
+		sqlSection map[string]string
+	)
+
+	result := []Database{}
+
+	err := json.Unmarshal([]byte(settings), &dictionary)
+	if err != nil {
+		return result, wraperror.Errorf(err, "Could not parse settings")
+	}
+
+	if rawSQL, isOK := dictionary["SQL"]; isOK {
+		err = json.Unmarshal(rawSQL, &sqlSection)
+		if err != nil {
+			return result, wraperror.Errorf(err, "Could not parse SQL")
+		}
+	}
+
+	backend := sqlSection["BACKEND"]
+
+	if connection := sqlSection["CONNECTION"]; len(connection) > 0 {
+		result = append(result, Database{
+			Name:     CoreDatabaseName,
+			URL:      connection,
+			Variable: option.CoreSettings.Envar + ".SQL.CONNECTION",
+		})
+	}
+
+	// Short-circuit exit.
+
+	if len(backend) == 0 || backend == settingsBackendSQL {
+		return result, nil
+	}
+
+	clusters, err := parseClusters(dictionary, backend)
+
+	return append(result, clusters...), wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+The getDatabases method lists the databases to check.
+DatabaseURL, if set, is the CORE database; the remaining databases come from Settings.
+Unparsable settings are ignored here; CheckSettings reports them.
+*/
+func (checkself *BasicCheckSelf) getDatabases() []Database {
+	result := []Database{}
+
+	if len(checkself.Settings) > 0 {
+		databases, err := ParseDatabases(checkself.Settings)
+		if err == nil {
+			result = databases
+		}
+	}
+
+	if len(checkself.DatabaseURL) > 0 {
+		result = slices.DeleteFunc(result, func(database Database) bool {
+			return database.Name == CoreDatabaseName
+		})
+		result = slices.Insert(result, 0, Database{
+			Name:     CoreDatabaseName,
+			URL:      checkself.DatabaseURL,
+			Variable: option.DatabaseURL.Envar,
+		})
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// parseClusters returns the clusters referenced by the backend section (e.g. "HYBRID": {"RES_FEAT": "RES"}).
+func parseClusters(dictionary map[string]json.RawMessage, backend string) ([]Database, error) {
+	var tableClusters map[string]string
+
+	result := []Database{}
+
+	err := json.Unmarshal(dictionary[backend], &tableClusters)
+	if err != nil {
+		return result, wraperror.Errorf(err, "Could not parse %s", backend)
+	}
+
+	clusterNames := []string{}
+	for _, clusterName := range tableClusters {
+		if !slices.Contains(clusterNames, clusterName) {
+			clusterNames = append(clusterNames, clusterName)
+		}
+	}
+
+	slices.Sort(clusterNames)
+
+	for _, clusterName := range clusterNames {
+		var cluster map[string]string
+
+		err = json.Unmarshal(dictionary[clusterName], &cluster)
+		if err != nil {
+			return result, wraperror.Errorf(err, "Could not parse cluster %s", clusterName)
+		}
+
+		result = append(result, Database{
+			Name:     clusterName,
+			URL:      cluster[settingsClusterURL],
+			Variable: option.CoreSettings.Envar + "." + clusterName + "." + settingsClusterURL,
+		})
+	}
+
+	return result, nil
+}
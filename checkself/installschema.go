@@ -0,0 +1,263 @@
+package checkself
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-databasing/checker"
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// FixIDSchema identifies the schema installation in the report of InstallSchema.
+const FixIDSchema = "fix-schema"
+
+// Schemes of databases that commit DDL implicitly.
+var implicitDDLCommitSchemes = []string{"mysql", "oci", "oracle"}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The FixSchema method installs the Senzing schema where it is missing (see
+InstallSchema) and writes a report in the OutputFormat.
+
+Input
+  - ctx: A context to control lifecycle.
+  - confirm: Asked before installing into each database.  Installation proceeds only if it returns true.
+
+Output
+  - Nothing is returned, except for an error if any finding is at or above the
+    FailOn severity (e.g. the schema is still not installed).  The report is
+    written to the Writer (default: os.Stdout).
+*/
+func (checkself *BasicCheckSelf) FixSchema(ctx context.Context, confirm func(database Database) bool) error {
+	failOn, err := checkself.getFailOn()
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	renderer, err := checkself.getRenderer()
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	report, err := checkself.InstallSchema(ctx, confirm)
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	err = renderer(checkself.getWriter(), report)
+	if err != nil {
+		return wraperror.Errorf(err, "Could not write report")
+	}
+
+	failureCount := len(report.AtOrAbove(failOn))
+	if failureCount > 0 {
+		err = wraperror.Errorf(errForPackage, "%d findings at or above %s severity detected", failureCount, failOn)
+	}
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+/*
+The InstallSchema method installs the Senzing schema into each database that
+does not have it, then runs the database-url and database-schema checks to confirm.
+
+The create-schema SQL for the dialect of the database is read from the resource
+path (schema/szcore-schema-<dialect>-create.sql) and applied in a transaction.
+PostgreSQL, MSSQL, and SQLite roll back a failed installation.  MySQL and Oracle
+commit each CREATE implicitly, so a failed installation may leave tables behind.
+
+Input
+  - ctx: A context to control lifecycle.
+  - confirm: Asked before installing into each database.  Installation proceeds only if it returns true.
+
+Output
+  - A report of the installation, under FixIDSchema, followed by the confirming checks.
+    Unless ShowSecrets is set, secrets in the report are masked (see RedactSecrets).
+  - An error if the confirming checks could not be run.
+*/
+func (checkself *BasicCheckSelf) InstallSchema(
+	ctx context.Context,
+	confirm func(database Database) bool,
+) (*Report, error) {
+	report := newEmptyReport()
+	resourcePath := checkself.getResourcePath(ctx)
+	startTime := time.Now()
+
+	for _, database := range checkself.getDatabases() {
+		report.addDatabaseFindings(
+			FixIDSchema,
+			database,
+			installSchema(ctx, database, resourcePath, confirm, report)...,
+		)
+	}
+
+	report.Results = append(report.Results, CheckResult{
+		CheckID:  FixIDSchema,
+		Duration: time.Since(startTime),
+		Reason:   "",
+		Status:   checkStatus(FixIDSchema, report, nil),
+	})
+
+	// Confirm.
+
+	confirmation := *checkself
+	confirmation.SelectedChecks = []string{CheckIDDatabaseURL, CheckIDDatabaseSchema}
+	confirmation.ShowSecrets = true
+	confirmation.SkippedChecks = nil
+
+	confirmed, err := confirmation.Run(ctx)
+	report.merge(confirmed)
+
+	if !checkself.ShowSecrets {
+		report.redact()
+	}
+
+	return report, wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The IsDDLTransactional function reports whether a failed InstallSchema into the
+database is rolled back.  MySQL and Oracle commit each CREATE implicitly, so
+the tables created before the failure remain.
+*/
+func IsDDLTransactional(databaseURL string) bool {
+	parsedURL, err := ParseDatabaseURL(databaseURL)
+	if err != nil {
+		return true
+	}
+
+	return !slices.Contains(implicitDDLCommitSchemes, parsedURL.Scheme)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// applySchemaDDL runs the statements of a Senzing schema DDL file in a single transaction.
+func applySchemaDDL(ctx context.Context, databaseConnector driver.Connector, ddl string) error {
+	sqlDB := sql.OpenDB(databaseConnector)
+	defer sqlDB.Close()
+
+	transaction, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return wraperror.Errorf(err, "begin transaction")
+	}
+
+	for _, statement := range splitSchemaDDL(ddl) {
+		_, err = transaction.ExecContext(ctx, statement)
+		if err != nil {
+			_ = transaction.Rollback()
+
+			return wraperror.Errorf(err, "%s", statement)
+		}
+	}
+
+	return wraperror.Errorf(transaction.Commit(), "commit transaction")
+}
+
+// installSchema installs the Senzing schema into one database, if it is missing and confirm agrees.
+func installSchema(
+	ctx context.Context,
+	database Database,
+	resourcePath string,
+	confirm func(database Database) bool,
+	report *Report,
+) []Finding {
+	var result []Finding
+
+	parsedURL, err := ParseDatabaseURL(database.URL)
+	if err != nil || parsedURL.Scheme == "db2" { // Reported by the confirming checks.
+		return result
+	}
+
+	report.AddCheck("Install Senzing schema for %s: %s", database.Name, database.URL)
+
+	databaseConnector, err := newDatabaseConnector(ctx, database.URL)
+	if err != nil {
+		return result // Reported by the confirming checks.
+	}
+
+	checker := &checker.BasicChecker{
+		DatabaseConnector: databaseConnector,
+	}
+
+	err = pingDatabase(ctx, databaseConnector)
+	if err != nil {
+		return append(result, newError(
+			database.Variable,
+			"",
+			"Could not connect to database "+database.Name+"; the Senzing schema was not installed.",
+			err,
+		))
+	}
+
+	// Connected, so an error means DSRC_RECORD could not be read: the schema is missing.
+	_, err = checker.IsSchemaInstalled(ctx)
+	if err == nil {
+		report.AddInfo("Database %s: the Senzing schema is already installed", database.Name)
+
+		return result
+	}
+
+	ddlFile := schemaDDLFile(resourcePath, parsedURL.Scheme)
+
+	ddl, err := os.ReadFile(ddlFile)
+	if err != nil {
+		return append(result, newError(
+			option.ResourcePath.Envar,
+			"",
+			"Could not read the Senzing schema DDL "+ddlFile+".",
+			err,
+		))
+	}
+
+	if !confirm(database) {
+		report.AddInfo("Database %s: installation of the Senzing schema declined", database.Name)
+
+		return result
+	}
+
+	err = applySchemaDDL(ctx, databaseConnector, string(ddl))
+	if err != nil && !IsDDLTransactional(database.URL) {
+		return append(result, newError(
+			database.Variable,
+			"",
+			"Could not install the Senzing schema from "+ddlFile+
+				". The tables created before the failure remain; drop them before trying again.",
+			err,
+		))
+	}
+
+	if err != nil {
+		return append(result, newError(
+			database.Variable,
+			"",
+			"Could not install the Senzing schema from "+ddlFile+".",
+			err,
+		))
+	}
+
+	report.AddInfo("Database %s: installed the Senzing schema from %s", database.Name, ddlFile)
+
+	return result
+}
+
+// pingDatabase verifies that the database can be connected to.
+func pingDatabase(ctx context.Context, databaseConnector driver.Connector) error {
+	sqlDB := sql.OpenDB(databaseConnector)
+	defer sqlDB.Close()
+
+	return wraperror.Errorf(sqlDB.PingContext(ctx), "ping")
+}
@@ -2,21 +2,18 @@ package checkself
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"strings"
 )
 
+// CheckIDEnvironmentVariables identifies the ListEnvironmentVariables check.
+const CheckIDEnvironmentVariables = "environment-variables"
+
 // ----------------------------------------------------------------------------
 // Interface methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) ListEnvironmentVariables(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
+func (checkself *BasicCheckSelf) ListEnvironmentVariables(ctx context.Context, report *Report) error {
 	_ = ctx
 
 	osEnviron := map[string]string{}
@@ -29,16 +26,16 @@ func (checkself *BasicCheckSelf) ListEnvironmentVariables(
 	}
 
 	if len(osEnviron) > 0 {
-		reportInfo = append(reportInfo, "\nSENZING_TOOLS_* environment variables defined:\n")
+		report.AddInfo("\nSENZING_TOOLS_* environment variables defined:\n")
 
 		count := 0
 		for key, value := range osEnviron {
 			count++
-			reportInfo = append(reportInfo, fmt.Sprintf("%6d. %s = %s", count, key, value))
+			report.AddInfo("%6d. %s = %s", count, key, value)
 		}
 
-		reportInfo = append(reportInfo, "")
+		report.AddInfo("")
 	}
 
-	return reportChecks, reportInfo, reportErrors, nil
+	return nil
 }
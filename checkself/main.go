@@ -9,9 +9,15 @@ import (
 // Types
 // ----------------------------------------------------------------------------
 
-// The ExamplePackage interface is an example interface.
+// The CheckSelf interface checks the environment in which Senzing runs.
 type CheckSelf interface {
 	CheckSelf(ctx context.Context) error
+	Checkers() []Checker
+	PublishReport(ctx context.Context, report *Report) error
+	RegisterChecker(checker Checker) error
+	ReorderCheckers(checkerIDs ...string) error
+	Run(ctx context.Context) (*Report, error)
+	UnregisterChecker(checkerID string) error
 }
 
 // ----------------------------------------------------------------------------
@@ -2,24 +2,21 @@ package checkself
 
 import (
 	"context"
-	"fmt"
 	"time"
 )
 
+// CheckIDProlog identifies the Prolog check.
+const CheckIDProlog = "prolog"
+
 // ----------------------------------------------------------------------------
 // Interface methods
 // ----------------------------------------------------------------------------
 
-func (checkself *BasicCheckSelf) Prolog(
-	ctx context.Context,
-	reportChecks []string,
-	reportInfo []string,
-	reportErrors []string,
-) ([]string, []string, []string, error) {
+func (checkself *BasicCheckSelf) Prolog(ctx context.Context, report *Report) error {
 	_ = ctx
 
-	reportInfo = append(reportInfo, fmt.Sprintf("Date: %s ", time.Now().UTC().Format(time.RFC3339)))
-	reportInfo = append(reportInfo, fmt.Sprintf("Version: %s-%s ", githubVersion, githubIteration))
+	report.AddInfo("Date: %s ", time.Now().UTC().Format(time.RFC3339))
+	report.AddInfo("Version: %s-%s ", githubVersion, githubIteration)
 
-	return reportChecks, reportInfo, reportErrors, nil
+	return nil
 }
@@ -0,0 +1,78 @@
+package checkself
+
+import (
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Renderer writes a report in a particular output format.
+type Renderer func(writer io.Writer, report *Report) error
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Output formats accepted by GetRenderer and BasicCheckSelf.OutputFormat.
+const (
+	OutputFormatJSON  = "json"
+	OutputFormatJUnit = "junit"
+	OutputFormatTAP   = "tap"
+	OutputFormatText  = "text"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var renderers = map[string]Renderer{
+	OutputFormatJSON:  RenderJSON,
+	OutputFormatJUnit: RenderJUnit,
+	OutputFormatTAP:   RenderTAP,
+	OutputFormatText:  RenderText,
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The GetRenderer function returns the Renderer for an output format.
+
+Input
+  - outputFormat: Case-insensitive name of the output format (e.g. "json").
+
+Output
+  - The Renderer, or an error if the output format is not supported.
+*/
+func GetRenderer(outputFormat string) (Renderer, error) {
+	result, isOK := renderers[strings.ToLower(outputFormat)]
+	if !isOK {
+		return nil, wraperror.Errorf(
+			errForPackage,
+			"unknown output format: %s; use one of %s",
+			outputFormat,
+			strings.Join(OutputFormats(), ", "),
+		)
+	}
+
+	return result, nil
+}
+
+// OutputFormats returns the names of the supported output formats, sorted.
+func OutputFormats() []string {
+	result := make([]string, 0, len(renderers))
+	for outputFormat := range renderers {
+		result = append(result, outputFormat)
+	}
+
+	slices.Sort(result)
+
+	return result
+}
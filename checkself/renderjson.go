@@ -0,0 +1,206 @@
+package checkself
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+JSONReport is the document written by RenderJSON.
+
+Fields are only added within a SchemaVersion.  Removing or changing the meaning
+of a field increments SchemaVersion.
+*/
+type JSONReport struct {
+	Checks        []string        `json:"checks"`        // Descriptions of checks performed.
+	Findings      []JSONFinding   `json:"findings"`      // Problems and observations found by the checks.
+	Info          []string        `json:"info"`          // Informational lines about the environment.
+	Results       []JSONResult    `json:"results"`       // Outcome of each registered check, in the order run.
+	SchemaVersion string          `json:"schemaVersion"` // Version of this document's structure.
+	Statistics    []JSONStatistic `json:"statistics"`    // Measurements of the Senzing repository.
+	Status        string          `json:"status"`        // "PASSED" if no finding is ERROR or higher, otherwise "FAILED".
+	Summary       map[string]int  `json:"summary"`       // Number of findings, keyed by severity name.
+}
+
+// JSONFinding is a Finding in a JSONReport.
+type JSONFinding struct {
+	CheckID        string `json:"checkId"`
+	Database       string `json:"database,omitempty"` // Logical database (e.g. CORE, RES), if any.
+	Error          string `json:"error,omitempty"`
+	Message        string `json:"message"`
+	RemediationURL string `json:"remediationUrl,omitempty"`
+	Severity       string `json:"severity"` // INFO, WARNING, ERROR, or CRITICAL.
+	Subject        string `json:"subject,omitempty"`
+	Value          string `json:"value,omitempty"`
+}
+
+// JSONResult is a CheckResult in a JSONReport.
+type JSONResult struct {
+	CheckID    string  `json:"checkId"`
+	DurationMs float64 `json:"durationMs"`
+	Reason     string  `json:"reason,omitempty"`
+	Status     string  `json:"status"` // PASSED, FAILED, SKIPPED, or TIMED OUT.
+}
+
+// JSONStatistic is a Statistic in a JSONReport.
+type JSONStatistic struct {
+	Database string `json:"database"`
+	Name     string `json:"name"`
+	Unit     string `json:"unit"` // rows, records, or bytes.
+	Value    int64  `json:"value"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// JSONSchemaVersion is the SchemaVersion of documents written by RenderJSON.
+const JSONSchemaVersion = "1"
+
+const (
+	statusFailed = "FAILED"
+	statusPassed = "PASSED"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The RenderJSON function writes the report as an indented JSONReport document.
+
+Input
+  - writer: Destination of the rendered report.
+  - report: The report to render.
+
+Output
+  - An error if the report could not be written.
+*/
+func RenderJSON(writer io.Writer, report *Report) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(NewJSONReport(report))
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+/*
+The NewJSONReport function converts a report to the structure written by RenderJSON.
+
+Input
+  - report: The report to convert.
+
+Output
+  - The JSON document structure.
+*/
+func NewJSONReport(report *Report) *JSONReport {
+	result := &JSONReport{
+		Checks:        nonNil(report.Checks),
+		Findings:      make([]JSONFinding, 0, len(report.Findings)),
+		Info:          nonNil(report.Info),
+		Results:       make([]JSONResult, 0, len(report.Results)),
+		SchemaVersion: JSONSchemaVersion,
+		Statistics:    make([]JSONStatistic, 0, len(report.Statistics)),
+		Status:        statusPassed,
+		Summary:       map[string]int{},
+	}
+
+	for _, severityName := range severityNames {
+		result.Summary[severityName] = 0
+	}
+
+	for _, finding := range report.Findings {
+		jsonFinding := JSONFinding{
+			CheckID:        finding.CheckID,
+			Database:       finding.Database,
+			Error:          "",
+			Message:        finding.Message,
+			RemediationURL: finding.RemediationURL,
+			Severity:       finding.Severity.String(),
+			Subject:        finding.Subject,
+			Value:          finding.Value,
+		}
+
+		if finding.Err != nil {
+			jsonFinding.Error = finding.Err.Error()
+		}
+
+		result.Findings = append(result.Findings, jsonFinding)
+		result.Summary[finding.Severity.String()]++
+	}
+
+	for _, checkResult := range report.Results {
+		result.Results = append(result.Results, JSONResult{
+			CheckID:    checkResult.CheckID,
+			DurationMs: durationMs(checkResult.Duration),
+			Reason:     checkResult.Reason,
+			Status:     checkResult.Status.String(),
+		})
+	}
+
+	for _, statistic := range report.Statistics {
+		result.Statistics = append(result.Statistics, JSONStatistic(statistic))
+	}
+
+	if len(report.Errors()) > 0 {
+		result.Status = statusFailed
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// redact masks secrets in every string of the document, like Report.redact.
+func (jsonReport *JSONReport) redact() {
+	// Checks and Info share their arrays with the Report.
+	jsonReport.Checks = slices.Clone(jsonReport.Checks)
+	jsonReport.Info = slices.Clone(jsonReport.Info)
+
+	for index := range jsonReport.Checks {
+		jsonReport.Checks[index] = RedactSecrets(jsonReport.Checks[index])
+	}
+
+	for index := range jsonReport.Info {
+		jsonReport.Info[index] = RedactSecrets(jsonReport.Info[index])
+	}
+
+	for index := range jsonReport.Findings {
+		finding := &jsonReport.Findings[index]
+		finding.Error = RedactSecrets(finding.Error)
+		finding.Message = RedactSecrets(finding.Message)
+		finding.Subject = RedactSecrets(finding.Subject)
+		finding.Value = RedactSecrets(finding.Value)
+	}
+
+	for index := range jsonReport.Results {
+		jsonReport.Results[index].Reason = RedactSecrets(jsonReport.Results[index].Reason)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func durationMs(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
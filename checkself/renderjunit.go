@@ -0,0 +1,155 @@
+package checkself
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Failures int              `xml:"failures,attr"`
+	Name     string           `xml:"name,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+	Tests    int              `xml:"tests,attr"`
+	Time     string           `xml:"time,attr"`
+}
+
+type junitTestSuite struct {
+	Cases    []junitTestCase `xml:"testcase"`
+	Failures int             `xml:"failures,attr"`
+	Name     string          `xml:"name,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Time     string          `xml:"time,attr"`
+}
+
+type junitTestCase struct {
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Name      string        `xml:"name,attr"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	Time      string        `xml:"time,attr"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const junitSuiteName = "check-self"
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The RenderJUnit function writes the report as JUnit XML.
+Each check result is a test case.  Failed and timed-out checks carry their
+ERROR and CRITICAL findings; skipped checks carry the reason.
+
+Input
+  - writer: Destination of the rendered report.
+  - report: The report to render.
+
+Output
+  - An error if the report could not be written.
+*/
+func RenderJUnit(writer io.Writer, report *Report) error {
+	var totalDuration time.Duration
+
+	suite := junitTestSuite{
+		Cases:    make([]junitTestCase, 0, len(report.Results)),
+		Failures: 0,
+		Name:     junitSuiteName,
+		Skipped:  0,
+		Tests:    len(report.Results),
+		Time:     "",
+	}
+
+	for _, checkResult := range report.Results {
+		testCase := junitTestCase{
+			Classname: junitSuiteName,
+			Failure:   nil,
+			Name:      checkResult.CheckID,
+			Skipped:   nil,
+			Time:      formatSeconds(checkResult.Duration),
+		}
+
+		switch checkResult.Status {
+		case CheckStatusFailed, CheckStatusTimedOut:
+			testCase.Failure = junitFailure(checkResult, report.findingsFor(checkResult.CheckID, SeverityError))
+			suite.Failures++
+		case CheckStatusSkipped:
+			testCase.Skipped = &junitMessage{Message: checkResult.Reason, Text: "", Type: ""}
+			suite.Skipped++
+		case CheckStatusPassed:
+		}
+
+		totalDuration += checkResult.Duration
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	suite.Time = formatSeconds(totalDuration)
+
+	document, err := xml.MarshalIndent(junitTestSuites{
+		XMLName:  xml.Name{Space: "", Local: "testsuites"},
+		Failures: suite.Failures,
+		Name:     junitSuiteName,
+		Skipped:  suite.Skipped,
+		Suites:   []junitTestSuite{suite},
+		Tests:    suite.Tests,
+		Time:     suite.Time,
+	}, "", "  ")
+	if err != nil {
+		return wraperror.Errorf(err, "Could not marshal JUnit XML")
+	}
+
+	_, err = io.WriteString(writer, xml.Header+string(document)+"\n")
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func formatSeconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
+
+func junitFailure(checkResult CheckResult, findings []Finding) *junitMessage {
+	result := &junitMessage{
+		Message: checkResult.Status.String(),
+		Text:    "",
+		Type:    checkResult.Status.String(),
+	}
+
+	if len(findings) > 0 {
+		result.Message = findings[0].String()
+		result.Type = findings[0].Severity.String()
+	}
+
+	lines := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		lines = append(lines, finding.Severity.String()+": "+finding.String())
+	}
+
+	result.Text = strings.Join(lines, "\n")
+
+	return result
+}
@@ -0,0 +1,78 @@
+package checkself
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The RenderTAP function writes the report in the Test Anything Protocol (TAP version 14).
+Each check result is a test point.  Skipped checks are marked "# SKIP" with the
+reason.  Findings of WARNING or higher are listed in a YAML diagnostic block
+beneath the check that produced them.
+
+Input
+  - writer: Destination of the rendered report.
+  - report: The report to render.
+
+Output
+  - An error if the report could not be written.
+*/
+func RenderTAP(writer io.Writer, report *Report) error {
+	var result strings.Builder
+
+	fmt.Fprintf(&result, "TAP version 14\n1..%d\n", len(report.Results))
+
+	for index, checkResult := range report.Results {
+		switch checkResult.Status {
+		case CheckStatusPassed:
+			fmt.Fprintf(&result, "ok %d - %s\n", index+1, checkResult.CheckID)
+		case CheckStatusSkipped:
+			fmt.Fprintf(&result, "ok %d - %s # SKIP %s\n", index+1, checkResult.CheckID, checkResult.Reason)
+
+			continue
+		case CheckStatusFailed, CheckStatusTimedOut:
+			fmt.Fprintf(&result, "not ok %d - %s\n", index+1, checkResult.CheckID)
+		}
+
+		writeTAPDiagnostics(&result, checkResult, report.findingsFor(checkResult.CheckID, SeverityWarning))
+	}
+
+	_, err := io.WriteString(writer, result.String())
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func writeTAPDiagnostics(builder *strings.Builder, checkResult CheckResult, findings []Finding) {
+	// Short-circuit exit.
+
+	if checkResult.Status == CheckStatusPassed && len(findings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(builder, "  ---\n  status: %s\n", strconv.Quote(checkResult.Status.String()))
+	fmt.Fprintf(builder, "  durationMs: %s\n", strconv.FormatFloat(durationMs(checkResult.Duration), 'f', -1, 64))
+
+	if len(findings) > 0 {
+		builder.WriteString("  findings:\n")
+
+		for _, finding := range findings {
+			fmt.Fprintf(builder, "    - severity: %s\n", finding.Severity.String())
+			fmt.Fprintf(builder, "      message: %s\n", strconv.Quote(finding.String()))
+		}
+	}
+
+	builder.WriteString("  ...\n")
+}
@@ -0,0 +1,112 @@
+package checkself
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The RenderText function writes the report in the human-readable format with horizontal rules.
+
+Input
+  - writer: Destination of the rendered report.
+  - report: The report to render.
+
+Output
+  - An error if the report could not be written.
+*/
+func RenderText(writer io.Writer, report *Report) error {
+	var result strings.Builder
+
+	observations := report.Observations()
+	if len(report.Info) > 0 || len(observations) > 0 {
+		writeTitle(&result, "Information")
+
+		for _, message := range report.Info {
+			fmt.Fprintln(&result, message)
+		}
+
+		for _, finding := range observations {
+			fmt.Fprintln(&result, finding.String())
+		}
+	}
+
+	if len(report.Statistics) > 0 {
+		writeTitle(&result, "Repository statistics")
+
+		for _, statistic := range report.Statistics {
+			fmt.Fprintln(&result, statistic.String())
+		}
+	}
+
+	if len(report.Checks) > 0 {
+		writeTitle(&result, "Checks performed")
+
+		for index, message := range report.Checks {
+			fmt.Fprintf(&result, "%6d. %s\n", index+1, message)
+		}
+	}
+
+	skipped := report.Skipped()
+	if len(skipped) > 0 {
+		writeTitle(&result, "Checks skipped")
+
+		for index, checkResult := range skipped {
+			fmt.Fprintf(&result, "%6d. %s: %s\n", index+1, checkResult.CheckID, checkResult.Reason)
+		}
+	}
+
+	warnings := report.Warnings()
+	if len(warnings) > 0 {
+		writeTitle(&result, "Warnings")
+
+		for index, finding := range warnings {
+			fmt.Fprintf(&result, "%6d. %s\n\n", index+1, finding.String())
+		}
+	}
+
+	reportErrors := report.Errors()
+	if len(reportErrors) > 0 {
+		writeTitle(&result, "Errors")
+
+		for index, finding := range reportErrors {
+			if finding.Severity == SeverityCritical {
+				fmt.Fprintf(&result, "%6d. CRITICAL: %s\n\n", index+1, finding.String())
+			} else {
+				fmt.Fprintf(&result, "%6d. %s\n\n", index+1, finding.String())
+			}
+		}
+
+		fmt.Fprintf(&result, "Result: %d errors detected\n", len(reportErrors))
+	} else {
+		writeTitle(&result, "Result")
+		fmt.Fprintf(&result, "No errors detected.\n")
+	}
+
+	if len(warnings) > 0 {
+		fmt.Fprintf(&result, "Result: %d warnings detected\n", len(warnings))
+	}
+
+	fmt.Fprintf(&result, "%s\n\n\n\n\n", strings.Repeat("-", horizontalRuleLength))
+
+	// Write the report in a single call so concurrent reports do not interleave.
+
+	_, err := io.WriteString(writer, result.String())
+
+	return wraperror.Errorf(err, wraperror.NoMessage)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func writeTitle(builder *strings.Builder, title string) {
+	fmt.Fprintf(builder, "\n-- %s %s\n\n", title, strings.Repeat("-", horizontalTitleLength-len(title)))
+}
@@ -0,0 +1,394 @@
+package checkself
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// CheckStatus is the outcome of running a single check.
+type CheckStatus int
+
+// CheckResult records the outcome of a single check.
+type CheckResult struct {
+	CheckID  string        // Identifier of the check.
+	Duration time.Duration // How long the check ran.  Zero if it was skipped.
+	Reason   string        // Why the check was skipped, if it was.
+	Status   CheckStatus   // Whether the check passed, failed, timed out, or was skipped.
+}
+
+// Severity ranks how serious a Finding is.
+type Severity int
+
+// Finding is a single, typed result produced by a check.
+type Finding struct {
+	CheckID        string   // Identifier of the check that produced the finding (e.g. "database-url").
+	Database       string   // Logical database the finding is about (e.g. "CORE", "RES"), if any.
+	Err            error    // Underlying error, if any.
+	Message        string   // Human readable description of the problem.
+	RemediationURL string   // Where to learn how to fix the problem.
+	Severity       Severity // How serious the finding is.
+	Subject        string   // Variable being checked (e.g. SENZING_TOOLS_DATABASE_URL).
+	Value          string   // Offending value of Subject, if any.
+}
+
+// Report is the structured outcome of CheckSelf.
+type Report struct {
+	Checks     []string      // Descriptions of checks performed.
+	Findings   []Finding     // Problems and observations found by the checks.
+	Info       []string      // Informational lines about the environment.
+	Results    []CheckResult // Outcome of each registered check, in the order run.
+	Statistics []Statistic   // Measurements of the Senzing repository.
+}
+
+// Statistic is a measurement of a Senzing repository (e.g. the number of records).
+type Statistic struct {
+	Database string // Logical database measured (e.g. "CORE", "RES").
+	Name     string // What was measured (e.g. "Records", "Data source CUSTOMERS").
+	Unit     string // Unit of Value (e.g. "rows", "bytes").
+	Value    int64  // The measurement.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	CheckStatusPassed CheckStatus = iota
+	CheckStatusFailed
+	CheckStatusSkipped
+	CheckStatusTimedOut
+)
+
+const (
+	SeverityInfo     Severity = iota // Observation; never a problem.
+	SeverityWarning                  // Should be looked at, but Senzing can run.
+	SeverityError                    // Misconfiguration that will cause Senzing to misbehave.
+	SeverityCritical                 // Senzing cannot run at all.
+)
+
+const defaultRemediationURL = "https://hub.senzing.com/..."
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var checkStatusNames = map[CheckStatus]string{
+	CheckStatusPassed:   "PASSED",
+	CheckStatusFailed:   "FAILED",
+	CheckStatusSkipped:  "SKIPPED",
+	CheckStatusTimedOut: "TIMED OUT",
+}
+
+var severityNames = map[Severity]string{
+	SeverityInfo:     "INFO",
+	SeverityWarning:  "WARNING",
+	SeverityError:    "ERROR",
+	SeverityCritical: "CRITICAL",
+}
+
+// ----------------------------------------------------------------------------
+// CheckStatus methods
+// ----------------------------------------------------------------------------
+
+// String returns the upper-case name of the status (e.g. "SKIPPED").
+func (status CheckStatus) String() string {
+	result, isOK := checkStatusNames[status]
+	if !isOK {
+		return fmt.Sprintf("CheckStatus(%d)", int(status))
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The ParseSeverity function converts a severity name (e.g. "warning") to a Severity.
+
+Input
+  - name: Case-insensitive name of the severity.
+
+Output
+  - The Severity, or an error if the name is not recognized.
+*/
+func ParseSeverity(name string) (Severity, error) {
+	for severity, severityName := range severityNames {
+		if strings.EqualFold(name, severityName) {
+			return severity, nil
+		}
+	}
+
+	return SeverityInfo, wraperror.Errorf(errForPackage, "unknown severity: %s", name)
+}
+
+// ----------------------------------------------------------------------------
+// Severity methods
+// ----------------------------------------------------------------------------
+
+// String returns the upper-case name of the severity (e.g. "ERROR").
+func (severity Severity) String() string {
+	result, isOK := severityNames[severity]
+	if !isOK {
+		return fmt.Sprintf("Severity(%d)", int(severity))
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Finding methods
+// ----------------------------------------------------------------------------
+
+/*
+The String method renders the finding as a single line of text.
+
+When Database is set, the text begins with "[Database] ".
+When Value is set, the text continues with "Subject = Value is misconfigured.".
+The remediation URL and the underlying error, if present, are appended.
+*/
+func (finding Finding) String() string {
+	var result strings.Builder
+
+	if len(finding.Database) > 0 {
+		fmt.Fprintf(&result, "[%s] ", finding.Database)
+	}
+
+	if len(finding.Subject) > 0 && len(finding.Value) > 0 {
+		fmt.Fprintf(&result, "%s = %s is misconfigured. ", finding.Subject, finding.Value)
+	}
+
+	result.WriteString(finding.Message)
+
+	if len(finding.RemediationURL) > 0 {
+		fmt.Fprintf(&result, " For more information, visit %s", finding.RemediationURL)
+	}
+
+	if finding.Err != nil {
+		fmt.Fprintf(&result, "  Error: %s", finding.Err.Error())
+	}
+
+	return result.String()
+}
+
+// ----------------------------------------------------------------------------
+// Statistic methods
+// ----------------------------------------------------------------------------
+
+// String renders the statistic as a single line of text (e.g. "[CORE] Records: 42 rows").
+func (statistic Statistic) String() string {
+	if len(statistic.Database) > 0 {
+		return fmt.Sprintf("[%s] %s: %d %s", statistic.Database, statistic.Name, statistic.Value, statistic.Unit)
+	}
+
+	return fmt.Sprintf("%s: %d %s", statistic.Name, statistic.Value, statistic.Unit)
+}
+
+// ----------------------------------------------------------------------------
+// Report methods
+// ----------------------------------------------------------------------------
+
+// AddCheck records the description of a check that was performed.
+func (report *Report) AddCheck(format string, messages ...any) {
+	report.Checks = append(report.Checks, fmt.Sprintf(format, messages...))
+}
+
+// AddFinding records a finding.
+func (report *Report) AddFinding(finding Finding) {
+	report.Findings = append(report.Findings, finding)
+}
+
+// AddInfo records an informational line.
+func (report *Report) AddInfo(format string, messages ...any) {
+	report.Info = append(report.Info, fmt.Sprintf(format, messages...))
+}
+
+// AddStatistic records a measurement of the Senzing repository.
+func (report *Report) AddStatistic(statistic Statistic) {
+	report.Statistics = append(report.Statistics, statistic)
+}
+
+// TimedOut returns the results of checks that did not complete in time.
+func (report *Report) TimedOut() []CheckResult {
+	return report.resultsWithStatus(CheckStatusTimedOut)
+}
+
+// Skipped returns the results of checks that were skipped.
+func (report *Report) Skipped() []CheckResult {
+	return report.resultsWithStatus(CheckStatusSkipped)
+}
+
+// AtOrAbove returns the findings having a severity of threshold or higher.
+func (report *Report) AtOrAbove(threshold Severity) []Finding {
+	var result []Finding
+
+	for _, finding := range report.Findings {
+		if finding.Severity >= threshold {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
+
+// Observations returns the findings having a severity of SeverityInfo.
+func (report *Report) Observations() []Finding {
+	var result []Finding
+
+	for _, finding := range report.Findings {
+		if finding.Severity == SeverityInfo {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
+
+// Errors returns the findings having a severity of SeverityError or higher.
+func (report *Report) Errors() []Finding {
+	return report.AtOrAbove(SeverityError)
+}
+
+// Warnings returns the findings having a severity of SeverityWarning.
+func (report *Report) Warnings() []Finding {
+	var result []Finding
+
+	for _, finding := range report.Findings {
+		if finding.Severity == SeverityWarning {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// addFindings records findings produced by a helper, attributing them to checkID.
+func (report *Report) addFindings(checkID string, findings ...Finding) {
+	for _, finding := range findings {
+		if len(finding.CheckID) == 0 {
+			finding.CheckID = checkID
+		}
+
+		report.AddFinding(finding)
+	}
+}
+
+// addDatabaseFindings records findings about a database, attributing them to checkID.
+func (report *Report) addDatabaseFindings(checkID string, database Database, findings ...Finding) {
+	for index := range findings {
+		if len(findings[index].Database) == 0 {
+			findings[index].Database = database.Name
+		}
+	}
+
+	report.addFindings(checkID, findings...)
+}
+
+// findingsFor returns the findings of a check having a severity of threshold or higher.
+func (report *Report) findingsFor(checkID string, threshold Severity) []Finding {
+	var result []Finding
+
+	for _, finding := range report.AtOrAbove(threshold) {
+		if finding.CheckID == checkID {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
+
+// merge appends the contents of another report.
+func (report *Report) merge(other *Report) {
+	report.Checks = append(report.Checks, other.Checks...)
+	report.Findings = append(report.Findings, other.Findings...)
+	report.Info = append(report.Info, other.Info...)
+	report.Results = append(report.Results, other.Results...)
+	report.Statistics = append(report.Statistics, other.Statistics...)
+}
+
+// redact masks secrets in every string of the report.  See RedactSecrets.
+func (report *Report) redact() {
+	for index := range report.Checks {
+		report.Checks[index] = RedactSecrets(report.Checks[index])
+	}
+
+	for index := range report.Info {
+		report.Info[index] = RedactSecrets(report.Info[index])
+	}
+
+	for index := range report.Findings {
+		finding := &report.Findings[index]
+		finding.Err = redactError(finding.Err)
+		finding.Message = RedactSecrets(finding.Message)
+		finding.Subject = RedactSecrets(finding.Subject)
+		finding.Value = RedactSecrets(finding.Value)
+	}
+
+	for index := range report.Results {
+		report.Results[index].Reason = RedactSecrets(report.Results[index].Reason)
+	}
+}
+
+func (report *Report) resultsWithStatus(status CheckStatus) []CheckResult {
+	var result []CheckResult
+
+	for _, checkResult := range report.Results {
+		if checkResult.Status == status {
+			result = append(result, checkResult)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// newCritical is a convenience constructor for SeverityCritical findings.
+func newCritical(subject string, value string, message string, err error) Finding {
+	return newFinding(SeverityCritical, subject, value, message, err)
+}
+
+// newError is a convenience constructor for SeverityError findings.
+func newError(subject string, value string, message string, err error) Finding {
+	return newFinding(SeverityError, subject, value, message, err)
+}
+
+// newInfo is a convenience constructor for SeverityInfo findings, which need no remediation.
+func newInfo(subject string, message string) Finding {
+	result := newFinding(SeverityInfo, subject, "", message, nil)
+	result.RemediationURL = ""
+
+	return result
+}
+
+func newFinding(severity Severity, subject string, value string, message string, err error) Finding {
+	return Finding{
+		CheckID:        "",
+		Database:       "",
+		Err:            err,
+		Message:        message,
+		RemediationURL: defaultRemediationURL,
+		Severity:       severity,
+		Subject:        subject,
+		Value:          value,
+	}
+}
+
+// newWarning is a convenience constructor for SeverityWarning findings.
+func newWarning(subject string, value string, message string, err error) Finding {
+	return newFinding(SeverityWarning, subject, value, message, err)
+}
@@ -0,0 +1,239 @@
+package checkself
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// checkRun is the state of a single check during Run.
+type checkRun struct {
+	checker      Checker
+	dependencies []*checkRun
+	done         chan struct{} // Closed when report and result are final.
+	report       *Report
+	result       CheckResult
+}
+
+// checkOutcome is what a checker goroutine hands back to its checkRun.
+type checkOutcome struct {
+	err    error
+	report *Report
+}
+
+const (
+	defaultCheckTimeout = 30 * time.Second
+	defaultConcurrency  = 4
+)
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+The runCheckers method runs the checkers on a bounded pool of goroutines.
+A checker starts once the checkers it depends on have finished.  Dependencies
+not among checkers are ignored.
+
+Output
+  - The state of each check, in registry order.
+*/
+func (checkself *BasicCheckSelf) runCheckers(ctx context.Context, checkers []Checker) []*checkRun {
+	var waitGroup sync.WaitGroup
+
+	runs := make([]*checkRun, 0, len(checkers))
+	runsByID := make(map[string]*checkRun, len(checkers))
+	semaphore := make(chan struct{}, checkself.getConcurrency())
+
+	for _, checker := range checkers {
+		run := &checkRun{
+			checker:      checker,
+			dependencies: []*checkRun{},
+			done:         make(chan struct{}),
+			report:       newEmptyReport(),
+			result: CheckResult{
+				CheckID:  checker.ID(),
+				Duration: 0,
+				Reason:   "",
+				Status:   CheckStatusSkipped,
+			},
+		}
+
+		for _, dependencyID := range checker.Dependencies() {
+			dependency, isOK := runsByID[dependencyID]
+
+			switch {
+			case isOK:
+				run.dependencies = append(run.dependencies, dependency)
+			case slices.ContainsFunc(checkers, func(other Checker) bool { return other.ID() == dependencyID }):
+				run.result.Reason = fmt.Sprintf("dependency %s is registered after %s", dependencyID, checker.ID())
+			}
+		}
+
+		runs = append(runs, run)
+		runsByID[checker.ID()] = run
+
+		waitGroup.Go(func() {
+			defer close(run.done)
+
+			checkself.runChecker(ctx, run, semaphore)
+		})
+	}
+
+	waitGroup.Wait()
+
+	return runs
+}
+
+/*
+The runChecker method runs a single checker unless one of its dependencies did not pass.
+A checker that does not return within CheckTimeout is abandoned and marked CheckStatusTimedOut.
+A checker that panics fails with a SeverityCritical finding.
+*/
+func (checkself *BasicCheckSelf) runChecker(ctx context.Context, run *checkRun, semaphore chan struct{}) {
+	for _, dependency := range run.dependencies {
+		<-dependency.done
+
+		if len(run.result.Reason) == 0 && dependency.result.Status != CheckStatusPassed {
+			run.result.Reason = fmt.Sprintf(
+				"dependency %s %s",
+				dependency.result.CheckID,
+				strings.ToLower(dependency.result.Status.String()),
+			)
+		}
+	}
+
+	// Short-circuit exit.
+
+	if len(run.result.Reason) > 0 {
+		return
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+		defer func() { <-semaphore }()
+	case <-ctx.Done():
+		run.result.Reason = "run ended before the check started"
+
+		return
+	}
+
+	// Run the check in its own goroutine so that a check which ignores ctx cannot hang Run.
+
+	checkTimeout := checkself.getCheckTimeout()
+	checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	outcomes := make(chan checkOutcome, 1)
+	startTime := time.Now()
+
+	go func() {
+		report := newEmptyReport()
+
+		// A panicking check fails on its own instead of ending the process.
+
+		defer func() {
+			recovered := recover()
+			if recovered != nil {
+				report.AddFinding(newCritical(
+					"",
+					"",
+					fmt.Sprintf("Check %s panicked.", run.checker.ID()),
+					wraperror.Errorf(errForPackage, "panic: %v", recovered),
+				))
+				outcomes <- checkOutcome{err: nil, report: report}
+			}
+		}()
+
+		err := run.checker.Check(checkCtx, report)
+		outcomes <- checkOutcome{err: err, report: report}
+	}()
+
+	select {
+	case outcome := <-outcomes:
+		run.result.Duration = time.Since(startTime)
+		run.report = outcome.report
+		run.result.Status = checkStatus(run.checker.ID(), outcome.report, outcome.err)
+	case <-checkCtx.Done():
+		run.result.Duration = time.Since(startTime)
+		run.result.Status = CheckStatusTimedOut
+		run.report.addFindings(run.checker.ID(), newError(
+			"",
+			"",
+			fmt.Sprintf("Check %s did not complete within %s.", run.checker.ID(), timeLimit(ctx, checkTimeout)),
+			checkCtx.Err(),
+		))
+	}
+}
+
+func (checkself *BasicCheckSelf) getCheckTimeout() time.Duration {
+	if checkself.CheckTimeout <= 0 {
+		return defaultCheckTimeout
+	}
+
+	return checkself.CheckTimeout
+}
+
+func (checkself *BasicCheckSelf) getConcurrency() int {
+	if checkself.Concurrency <= 0 {
+		return defaultConcurrency
+	}
+
+	return checkself.Concurrency
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+/*
+The checkStatus function determines whether a completed check passed and
+attributes findings added without a CheckID to the check.
+*/
+func checkStatus(checkID string, report *Report, err error) CheckStatus {
+	if err != nil && len(report.Errors()) == 0 {
+		report.AddFinding(newError("", "", "Check could not be completed.", err))
+	}
+
+	result := CheckStatusPassed
+
+	for index := range report.Findings {
+		if len(report.Findings[index].CheckID) == 0 {
+			report.Findings[index].CheckID = checkID
+		}
+
+		if report.Findings[index].Severity >= SeverityError {
+			result = CheckStatusFailed
+		}
+	}
+
+	return result
+}
+
+func newEmptyReport() *Report {
+	return &Report{
+		Checks:     []string{},
+		Findings:   []Finding{},
+		Info:       []string{},
+		Results:    []CheckResult{},
+		Statistics: []Statistic{},
+	}
+}
+
+// timeLimit describes the limit that expired: the run deadline if it came first, otherwise the check timeout.
+func timeLimit(ctx context.Context, checkTimeout time.Duration) string {
+	if ctx.Err() != nil {
+		return "the run deadline"
+	}
+
+	return checkTimeout.String()
+}
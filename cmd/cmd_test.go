@@ -1,10 +1,15 @@
 package cmd_test
 
 import (
+	"bytes"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/senzing-garage/check-self/checkself"
 	"github.com/senzing-garage/check-self/cmd"
+	"github.com/stretchr/testify/require"
 )
 
 // ----------------------------------------------------------------------------
@@ -19,6 +24,38 @@ func Test_Execute(test *testing.T) {
 	cmd.Execute()
 }
 
+func Test_FixSchemaAction(test *testing.T) {
+	test.Parallel()
+
+	contents, err := os.ReadFile("../testdata/sqlite/G2C-empty.db")
+	require.NoError(test, err)
+
+	databaseFile := filepath.Join(test.TempDir(), "G2C.db")
+	require.NoError(test, os.WriteFile(databaseFile, contents, 0o600))
+
+	buffer := &bytes.Buffer{}
+	prompt := &bytes.Buffer{}
+	checkSelf := &checkself.BasicCheckSelf{
+		DatabaseURL:  "sqlite3://na:password@" + databaseFile,
+		ResourcePath: "../testdata/resources",
+		Writer:       buffer,
+	}
+	err = cmd.FixSchemaAction(test.Context(), checkSelf, strings.NewReader("y\n"), prompt, false)
+	require.NoError(test, err)
+	require.Contains(test, prompt.String(), "Install the Senzing schema into CORE (sqlite3://na:xxxxx@")
+	require.Contains(test, buffer.String(), "installed the Senzing schema from")
+}
+
+func Test_ListAction(test *testing.T) {
+	test.Parallel()
+
+	buffer := &bytes.Buffer{}
+	err := cmd.ListAction(buffer)
+	require.NoError(test, err)
+	require.Contains(test, buffer.String(), "DEPENDS ON")
+	require.Regexp(test, `(?m)^database-schema +database +database-url +Verify`, buffer.String())
+}
+
 // func Test_Execute_completion(test *testing.T) {
 // 	test.Parallel()
 
@@ -0,0 +1,129 @@
+/*
+ */
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/senzing-garage/check-self/checkself"
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var Yes = option.ContextVariable{
+	Arg:     "yes",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_YES", false),
+	Envar:   "SENZING_TOOLS_YES",
+	Help:    "Make changes without asking for confirmation [%s]",
+	Type:    optiontype.Bool,
+}
+
+var FixSchemaContextVariables = []option.ContextVariable{
+	option.Configuration,
+	option.CoreSettings,
+	option.DatabaseURL,
+	FailOn,
+	OutputFormat,
+	option.ResourcePath,
+	ShowSecrets,
+	Yes,
+}
+
+// FixCmd represents the fix command.
+var FixCmd = &cobra.Command{
+	Use:   "fix",
+	Short: "Repair problems found by check-self",
+}
+
+// FixSchemaCmd represents the fix schema command.
+var FixSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Install the Senzing schema into databases that do not have it",
+	Long: `Install the Senzing schema into each configured database that does not have it,
+using the create-schema SQL for the database's dialect under the resource path
+(schema/szcore-schema-<dialect>-create.sql), then check the schema again.
+Each installation is confirmed on standard input unless --yes is given.
+MySQL and Oracle commit each CREATE implicitly, so a failed installation is
+not rolled back there.`,
+	PreRun: func(cobraCommand *cobra.Command, args []string) {
+		cmdhelper.PreRun(cobraCommand, args, Use, FixSchemaContextVariables)
+	},
+	RunE: func(cobraCommand *cobra.Command, args []string) error {
+		_ = args
+
+		checkSelf := &checkself.BasicCheckSelf{
+			DatabaseURL:  viper.GetString(option.DatabaseURL.Arg),
+			FailOn:       viper.GetString(FailOn.Arg),
+			OutputFormat: viper.GetString(OutputFormat.Arg),
+			ResourcePath: viper.GetString(option.ResourcePath.Arg),
+			Settings:     viper.GetString(option.CoreSettings.Arg),
+			ShowSecrets:  viper.GetBool(ShowSecrets.Arg),
+			Writer:       cobraCommand.OutOrStdout(),
+		}
+
+		return FixSchemaAction(
+			context.Background(),
+			checkSelf,
+			cobraCommand.InOrStdin(),
+			cobraCommand.ErrOrStderr(),
+			viper.GetBool(Yes.Arg),
+		)
+	},
+}
+
+func init() {
+	cmdhelper.Init(FixSchemaCmd, FixSchemaContextVariables)
+	FixCmd.AddCommand(FixSchemaCmd)
+	RootCmd.AddCommand(FixCmd)
+}
+
+// FixSchemaAction installs the Senzing schema, asking on in before each installation unless yes is set.
+func FixSchemaAction(
+	ctx context.Context,
+	checkSelf *checkself.BasicCheckSelf,
+	in io.Reader,
+	prompt io.Writer,
+	yes bool,
+) error {
+	reader := bufio.NewReader(in)
+
+	confirm := func(database checkself.Database) bool {
+		if yes {
+			return true
+		}
+
+		databaseURL := database.URL
+		if !checkSelf.ShowSecrets {
+			databaseURL = checkself.RedactSecrets(databaseURL)
+		}
+
+		if !checkself.IsDDLTransactional(database.URL) {
+			_, _ = fmt.Fprintf(
+				prompt,
+				"Database %s commits each CREATE implicitly; a failed installation is not rolled back.\n",
+				database.Name,
+			)
+		}
+
+		_, _ = fmt.Fprintf(prompt, "Install the Senzing schema into %s (%s)? [y/N] ", database.Name, databaseURL)
+
+		answer, _ := reader.ReadString('\n')
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true
+		default:
+			return false
+		}
+	}
+
+	return wraperror.Errorf(checkSelf.FixSchema(ctx, confirm), wraperror.NoMessage)
+}
@@ -0,0 +1,68 @@
+/*
+ */
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/senzing-garage/check-self/checkself"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/spf13/cobra"
+)
+
+const tabwriterPadding = 2
+
+// ListCmd represents the list command.
+var ListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available checks",
+	Long: `List every available check with its groups, dependencies, and description.
+Check IDs and groups may be given to --checks and --skip.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = args
+
+		return ListAction(cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(ListCmd)
+}
+
+// ListAction writes a table of the checks registered by default.
+func ListAction(out io.Writer) error {
+	checkSelf := &checkself.BasicCheckSelf{}
+	writer := tabwriter.NewWriter(out, 0, 0, tabwriterPadding, ' ', 0)
+
+	_, err := fmt.Fprintln(writer, "ID\tGROUPS\tDEPENDS ON\tDESCRIPTION")
+	if err != nil {
+		return wraperror.Errorf(err, "printing header")
+	}
+
+	for _, checker := range checkSelf.Checkers() {
+		_, err = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\n",
+			checker.ID(),
+			joinOrDash(checker.Groups()),
+			joinOrDash(checker.Dependencies()),
+			checker.Description(),
+		)
+		if err != nil {
+			return wraperror.Errorf(err, "printing %s", checker.ID())
+		}
+	}
+
+	return wraperror.Errorf(writer.Flush(), "flushing table")
+}
+
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+
+	return strings.Join(values, ",")
+}
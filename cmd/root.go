@@ -5,10 +5,13 @@ package cmd
 import (
 	"context"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/senzing-garage/check-self/checkself"
 	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
 	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
 	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -26,22 +29,158 @@ check-self long description.
 // Context variables
 // ----------------------------------------------------------------------------
 
+var CertificateDaysLeft = option.ContextVariable{
+	Arg:     "certificate-days-left",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_CERTIFICATE_DAYS_LEFT", 30),
+	Envar:   "SENZING_TOOLS_CERTIFICATE_DAYS_LEFT",
+	Help:    "Warn when a database or gRPC TLS certificate expires within this many days [%s]",
+	Type:    optiontype.Int,
+}
+
+var CheckTimeout = option.ContextVariable{
+	Arg:     "check-timeout",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_CHECK_TIMEOUT", "30s"),
+	Envar:   "SENZING_TOOLS_CHECK_TIMEOUT",
+	Help:    "Limit for each check (e.g. 30s, 2m) [%s]",
+	Type:    optiontype.String,
+}
+
+var ClientCACertificateFile = option.ContextVariable{
+	Arg:     "client-ca-certificate-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_CLIENT_CA_CERTIFICATE_FILE", ""),
+	Envar:   "SENZING_TOOLS_CLIENT_CA_CERTIFICATE_FILE",
+	Help:    "PEM file of CA certificates that sign gRPC and observer server certificates; enables TLS [%s]",
+	Type:    optiontype.String,
+}
+
+var ClientCertificateFile = option.ContextVariable{
+	Arg:     "client-certificate-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_CLIENT_CERTIFICATE_FILE", ""),
+	Envar:   "SENZING_TOOLS_CLIENT_CERTIFICATE_FILE",
+	Help:    "PEM file of the client certificate presented to gRPC and observer servers (mTLS) [%s]",
+	Type:    optiontype.String,
+}
+
+var ClientKeyFile = option.ContextVariable{
+	Arg:     "client-key-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_CLIENT_KEY_FILE", ""),
+	Envar:   "SENZING_TOOLS_CLIENT_KEY_FILE",
+	Help:    "PEM file of the private key of the client certificate [%s]",
+	Type:    optiontype.String,
+}
+
+var Concurrency = option.ContextVariable{
+	Arg:     "concurrency",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_CONCURRENCY", 4),
+	Envar:   "SENZING_TOOLS_CONCURRENCY",
+	Help:    "Maximum number of checks run at once [%s]",
+	Type:    optiontype.Int,
+}
+
+var FailOn = option.ContextVariable{
+	Arg:     "fail-on",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_FAIL_ON", "error"),
+	Envar:   "SENZING_TOOLS_FAIL_ON",
+	Help:    "Minimum severity of finding that causes a non-zero exit: info, warning, error, or critical [%s]",
+	Type:    optiontype.String,
+}
+
+var ObserverPublish = option.ContextVariable{
+	Arg:     "observer-publish",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_OBSERVER_PUBLISH", false),
+	Envar:   "SENZING_TOOLS_OBSERVER_PUBLISH",
+	Help:    "Send the report to the observer at --observer-url when the checks are done [%s]",
+	Type:    optiontype.Bool,
+}
+
+var ObserverSubjectID = option.ContextVariable{
+	Arg:     "observer-subject-id",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_OBSERVER_SUBJECT_ID", ""),
+	Envar:   "SENZING_TOOLS_OBSERVER_SUBJECT_ID",
+	Help:    "subjectId of messages sent to --observer-url; the component ID registered for check-self [%s]",
+	Type:    optiontype.String,
+}
+
+var OutputFormat = option.ContextVariable{
+	Arg:     "output-format",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_OUTPUT_FORMAT", checkself.OutputFormatText),
+	Envar:   "SENZING_TOOLS_OUTPUT_FORMAT",
+	Help:    "Format of the report: " + strings.Join(checkself.OutputFormats(), ", ") + " [%s]",
+	Type:    optiontype.String,
+}
+
+var Policy = option.ContextVariable{
+	Arg:     "policy",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_POLICY", checkself.PolicyDevelopment),
+	Envar:   "SENZING_TOOLS_POLICY",
+	Help:    "Policy to check against: development, or production to require encrypted connections [%s]",
+	Type:    optiontype.String,
+}
+
+var SelectedChecks = option.ContextVariable{
+	Arg:     "checks",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_CHECKS",
+	Help:    "Comma-delimited list of check IDs or groups to run; see \"check-self list\" [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var ShowSecrets = option.ContextVariable{
+	Arg:     "show-secrets",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_SHOW_SECRETS", false),
+	Envar:   "SENZING_TOOLS_SHOW_SECRETS",
+	Help:    "Show passwords and license strings in the report.  For local debugging only. [%s]",
+	Type:    optiontype.Bool,
+}
+
+var SkippedChecks = option.ContextVariable{
+	Arg:     "skip",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_SKIP",
+	Help:    "Comma-delimited list of check IDs or groups not to run; see \"check-self list\" [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var Timeout = option.ContextVariable{
+	Arg:     "timeout",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_TIMEOUT", ""),
+	Envar:   "SENZING_TOOLS_TIMEOUT",
+	Help:    "Limit for the whole run (e.g. 5m); empty for no limit [%s]",
+	Type:    optiontype.String,
+}
+
 var ContextVariablesForMultiPlatform = []option.ContextVariable{
+	CertificateDaysLeft,
+	CheckTimeout,
+	ClientCACertificateFile,
+	ClientCertificateFile,
+	ClientKeyFile,
+	Concurrency,
 	option.ConfigPath,
 	option.Configuration,
 	option.CoreLogLevel,
 	option.CoreSettings,
 	option.DatabaseURL,
+	FailOn,
 	option.GrpcURL,
 	option.InputURL,
 	option.LicenseDaysLeft,
 	option.LicenseRecordsPercent,
 	option.LicenseStringBase64,
 	option.LogLevel,
+	option.ObserverOrigin,
+	ObserverPublish,
+	ObserverSubjectID,
 	option.ObserverURL,
+	OutputFormat,
+	Policy,
 	option.ResourcePath,
+	SelectedChecks,
 	option.SenzingDirectory,
+	ShowSecrets,
+	SkippedChecks,
 	option.SupportPath,
+	Timeout,
 }
 
 var ContextVariables = append(ContextVariablesForMultiPlatform, ContextVariablesForOsArch...)
@@ -58,6 +197,9 @@ var RootCmd = &cobra.Command{
 	PreRun:  PreRun,
 	RunE:    RunE,
 	Version: Version(),
+
+	// Usage shows flag defaults, which are read from SENZING_TOOLS_* and may contain secrets.
+	SilenceUsage: true,
 }
 
 // ----------------------------------------------------------------------------
@@ -79,27 +221,54 @@ func PreRun(cobraCommand *cobra.Command, args []string) {
 }
 
 // Used in construction of cobra.Command.
-func RunE(_ *cobra.Command, _ []string) error {
+func RunE(cobraCommand *cobra.Command, _ []string) error {
 	ctx := context.Background()
 
+	checkTimeout, err := getDuration(CheckTimeout)
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
+	timeout, err := getDuration(Timeout)
+	if err != nil {
+		return wraperror.Errorf(err, wraperror.NoMessage)
+	}
+
 	checkSelf := &checkself.BasicCheckSelf{
+		CertificateDaysLeft:        viper.GetInt(CertificateDaysLeft.Arg),
+		CheckTimeout:               checkTimeout,
+		ClientCACertificateFile:    viper.GetString(ClientCACertificateFile.Arg),
+		ClientCertificateFile:      viper.GetString(ClientCertificateFile.Arg),
+		ClientKeyFile:              viper.GetString(ClientKeyFile.Arg),
+		Concurrency:                viper.GetInt(Concurrency.Arg),
 		ConfigPath:                 viper.GetString(option.ConfigPath.Arg),
 		DatabaseURL:                viper.GetString(option.DatabaseURL.Arg),
 		Settings:                   viper.GetString(option.CoreSettings.Arg),
 		EngineLogLevel:             viper.GetString(option.CoreLogLevel.Arg),
 		ErrorLicenseDaysLeft:       viper.GetString(option.LicenseDaysLeft.Arg),
 		ErrorLicenseRecordsPercent: viper.GetString(option.LicenseRecordsPercent.Arg),
-		GrpcURL:                    viper.GetString(option.GrpcPort.Arg),
+		FailOn:                     viper.GetString(FailOn.Arg),
+		GrpcURL:                    viper.GetString(option.GrpcURL.Arg),
 		InputURL:                   viper.GetString(option.InputURL.Arg),
 		LicenseStringBase64:        viper.GetString(option.LicenseStringBase64.Arg),
 		LogLevel:                   viper.GetString(option.LogLevel.Arg),
-		ObserverURL:                viper.GetString(option.ObserverGrpcPort.Arg),
+		ObserverOrigin:             viper.GetString(option.ObserverOrigin.Arg),
+		ObserverSubjectID:          viper.GetString(ObserverSubjectID.Arg),
+		ObserverURL:                viper.GetString(option.ObserverURL.Arg),
+		OutputFormat:               viper.GetString(OutputFormat.Arg),
+		Policy:                     viper.GetString(Policy.Arg),
+		PublishToObserver:          viper.GetBool(ObserverPublish.Arg),
 		ResourcePath:               viper.GetString(option.ResourcePath.Arg),
+		SelectedChecks:             viper.GetStringSlice(SelectedChecks.Arg),
 		SenzingDirectory:           viper.GetString(option.SenzingDirectory.Arg),
+		ShowSecrets:                viper.GetBool(ShowSecrets.Arg),
+		SkippedChecks:              viper.GetStringSlice(SkippedChecks.Arg),
 		SupportPath:                viper.GetString(option.SupportPath.Arg),
+		Timeout:                    timeout,
+		Writer:                     cobraCommand.OutOrStdout(),
 	}
 
-	err := checkSelf.CheckSelf(ctx)
+	err = checkSelf.CheckSelf(ctx)
 
 	return wraperror.Errorf(err, wraperror.NoMessage)
 }
@@ -113,6 +282,18 @@ func Version() string {
 // Private functions
 // ----------------------------------------------------------------------------
 
+// getDuration parses a duration context variable (e.g. "30s").  An empty value is zero.
+func getDuration(contextVariable option.ContextVariable) (time.Duration, error) {
+	value := viper.GetString(contextVariable.Arg)
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	result, err := time.ParseDuration(value)
+
+	return result, wraperror.Errorf(err, "--%s", contextVariable.Arg)
+}
+
 // Since init() is always invoked, define command line parameters.
 func init() {
 	cmdhelper.Init(RootCmd, ContextVariables)
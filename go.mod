@@ -6,8 +6,10 @@ require (
 	github.com/senzing-garage/go-cmdhelping v0.3.8
 	github.com/senzing-garage/go-databasing v0.5.9
 	github.com/senzing-garage/go-helpers v0.6.15
+	github.com/senzing-garage/go-observing v0.3.7
 	github.com/senzing-garage/go-sdk-abstract-factory v0.9.17
 	github.com/senzing-garage/sz-sdk-go v0.15.12
+	github.com/senzing-garage/sz-sdk-proto v0.8.8
 	github.com/spf13/cobra v1.10.2
 	github.com/spf13/viper v1.21.0
 	github.com/stretchr/testify v1.11.1
@@ -38,11 +40,9 @@ require (
 	github.com/sagikazarmark/locafero v0.12.0 // indirect
 	github.com/senzing-garage/go-logging v1.5.4 // indirect
 	github.com/senzing-garage/go-messaging v1.5.3 // indirect
-	github.com/senzing-garage/go-observing v0.3.7 // indirect
 	github.com/senzing-garage/sz-sdk-go-core v0.9.14 // indirect
 	github.com/senzing-garage/sz-sdk-go-grpc v0.9.12 // indirect
 	github.com/senzing-garage/sz-sdk-go-mock v0.8.14 // indirect
-	github.com/senzing-garage/sz-sdk-proto v0.8.8 // indirect
 	github.com/shopspring/decimal v1.4.0 // indirect
 	github.com/spf13/afero v1.15.0 // indirect
 	github.com/spf13/cast v1.10.0 // indirect
@@ -0,0 +1,19 @@
+/*
+Package szfake implements in-memory test doubles of the Senzing SDK interfaces
+used by check-self, so that engine checks can be tested without a Senzing
+installation.
+
+Supply an AbstractFactory through BasicCheckSelf.SzAbstractFactoryCreator:
+
+	testObject.SzAbstractFactoryCreator = func(ctx context.Context) (senzing.SzAbstractFactory, error) {
+		return &szfake.AbstractFactory{
+			ConfigManager: &szfake.ConfigManager{DefaultConfigID: 0},
+		}, nil
+	}
+
+To test the checks against a Senzing gRPC server, serve an AbstractFactory with
+NewGrpcServer and set BasicCheckSelf.GrpcURL to the address it listens on.
+Likewise, serve an Observer with NewObserverGrpcServer and set
+BasicCheckSelf.ObserverURL to record the messages check-self sends.
+*/
+package szfake
@@ -0,0 +1,158 @@
+package szfake
+
+import (
+	"context"
+	"sync"
+
+	szconfigmanagerpb "github.com/senzing-garage/sz-sdk-proto/go/szconfigmanager"
+	szdiagnosticpb "github.com/senzing-garage/sz-sdk-proto/go/szdiagnostic"
+	szproductpb "github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type configManagerServer struct {
+	szconfigmanagerpb.UnimplementedSzConfigManagerServer
+
+	factory *AbstractFactory
+	mutex   *sync.Mutex
+}
+
+type diagnosticServer struct {
+	szdiagnosticpb.UnimplementedSzDiagnosticServer
+
+	factory *AbstractFactory
+	mutex   *sync.Mutex
+}
+
+type productServer struct {
+	szproductpb.UnimplementedSzProductServer
+
+	factory *AbstractFactory
+	mutex   *sync.Mutex
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewGrpcServer function returns a gRPC server answering like a Senzing
+serve-grpc server from the objects of factory.  It serves
+SzConfigManager.GetDefaultConfigId, SzDiagnostic.GetRepositoryInfo,
+SzProduct.GetLicense, and SzProduct.GetVersion, one request at a time.  Errors
+of the test doubles are returned with codes.Unknown.
+
+Input
+  - factory: The test doubles to serve.
+  - serverOptions: Options of the gRPC server (e.g. TLS credentials).
+
+Output
+  - A server ready to Serve a net.Listener.
+*/
+func NewGrpcServer(factory *AbstractFactory, serverOptions ...grpc.ServerOption) *grpc.Server {
+	mutex := &sync.Mutex{}
+	result := grpc.NewServer(serverOptions...)
+	szconfigmanagerpb.RegisterSzConfigManagerServer(result, &configManagerServer{factory: factory, mutex: mutex})
+	szdiagnosticpb.RegisterSzDiagnosticServer(result, &diagnosticServer{factory: factory, mutex: mutex})
+	szproductpb.RegisterSzProductServer(result, &productServer{factory: factory, mutex: mutex})
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// gRPC methods
+// ----------------------------------------------------------------------------
+
+func (server *configManagerServer) GetDefaultConfigId(
+	ctx context.Context,
+	request *szconfigmanagerpb.GetDefaultConfigIdRequest,
+) (*szconfigmanagerpb.GetDefaultConfigIdResponse, error) {
+	_ = request
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	configManager, err := server.factory.CreateConfigManager(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result, err := configManager.GetDefaultConfigID(ctx)
+
+	return &szconfigmanagerpb.GetDefaultConfigIdResponse{Result: result}, toStatus(err)
+}
+
+func (server *diagnosticServer) GetRepositoryInfo(
+	ctx context.Context,
+	request *szdiagnosticpb.GetRepositoryInfoRequest,
+) (*szdiagnosticpb.GetRepositoryInfoResponse, error) {
+	_ = request
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	diagnostic, err := server.factory.CreateDiagnostic(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result, err := diagnostic.GetRepositoryInfo(ctx)
+
+	return &szdiagnosticpb.GetRepositoryInfoResponse{Result: result}, toStatus(err)
+}
+
+func (server *productServer) GetLicense(
+	ctx context.Context,
+	request *szproductpb.GetLicenseRequest,
+) (*szproductpb.GetLicenseResponse, error) {
+	_ = request
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	product, err := server.factory.CreateProduct(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result, err := product.GetLicense(ctx)
+
+	return &szproductpb.GetLicenseResponse{Result: result}, toStatus(err)
+}
+
+func (server *productServer) GetVersion(
+	ctx context.Context,
+	request *szproductpb.GetVersionRequest,
+) (*szproductpb.GetVersionResponse, error) {
+	_ = request
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	product, err := server.factory.CreateProduct(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result, err := product.GetVersion(ctx)
+
+	return &szproductpb.GetVersionResponse{Result: result}, toStatus(err)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return status.Error(codes.Unknown, err.Error())
+}
@@ -0,0 +1,76 @@
+package szfake
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/senzing-garage/go-observing/observerpb"
+	"google.golang.org/grpc"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Observer is an observer gRPC service that records the messages it receives.
+type Observer struct {
+	observerpb.UnimplementedObserverServer
+
+	UpdateErr error // If set, returned by UpdateObserver and the message is not recorded.
+	messages  []string
+	mutex     sync.Mutex
+}
+
+// ----------------------------------------------------------------------------
+// Observer methods
+// ----------------------------------------------------------------------------
+
+// Messages returns the messages received, in order.
+func (observer *Observer) Messages() []string {
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+
+	return slices.Clone(observer.messages)
+}
+
+// UpdateObserver records the message, or returns UpdateErr if it is set.
+func (observer *Observer) UpdateObserver(
+	ctx context.Context,
+	request *observerpb.UpdateObserverRequest,
+) (*observerpb.UpdateObserverResponse, error) {
+	_ = ctx
+
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+
+	if observer.UpdateErr != nil {
+		return nil, toStatus(observer.UpdateErr)
+	}
+
+	observer.messages = append(observer.messages, request.GetMessage())
+
+	return &observerpb.UpdateObserverResponse{}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewObserverGrpcServer function returns a gRPC server answering like a
+Senzing observer at an ObserverURL.
+
+Input
+  - observer: The test double recording the messages.
+  - serverOptions: Options of the gRPC server (e.g. TLS credentials).
+
+Output
+  - A server ready to Serve a net.Listener.
+*/
+func NewObserverGrpcServer(observer *Observer, serverOptions ...grpc.ServerOption) *grpc.Server {
+	result := grpc.NewServer(serverOptions...)
+	observerpb.RegisterObserverServer(result, observer)
+
+	return result
+}
@@ -0,0 +1,390 @@
+package szfake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+AbstractFactory is a senzing.SzAbstractFactory that returns the ConfigManager,
+Diagnostic, and Product it holds.  CreateEngine is not supported.
+*/
+type AbstractFactory struct {
+	Closed        bool           // Set by Close.
+	ConfigManager *ConfigManager // Returned by CreateConfigManager.  Default: DefaultConfigID 1.
+	CreateErr     error          // If set, returned by every Create method.
+	Diagnostic    *Diagnostic    // Returned by CreateDiagnostic.  Default: one SQLite data store.
+	Product       *Product       // Returned by CreateProduct.  Default: Senzing 4.0.0 and a license that never expires.
+}
+
+// ConfigManager is a senzing.SzConfigManager holding only a default configuration ID.
+type ConfigManager struct {
+	DefaultConfigID    int64 // Returned by GetDefaultConfigID.
+	DefaultConfigIDErr error // If set, returned by GetDefaultConfigID.
+	DestroyErr         error // If set, returned by Destroy.
+	Destroyed          bool  // Set by Destroy.
+}
+
+// Diagnostic is a senzing.SzDiagnostic holding only repository information.
+type Diagnostic struct {
+	DestroyErr        error  // If set, returned by Destroy.
+	Destroyed         bool   // Set by Destroy.
+	RepositoryInfo    string // Returned by GetRepositoryInfo.
+	RepositoryInfoErr error  // If set, returned by GetRepositoryInfo.
+}
+
+// Product is a senzing.SzProduct returning a fixed license and version.
+type Product struct {
+	DestroyErr error  // If set, returned by Destroy.
+	Destroyed  bool   // Set by Destroy.
+	License    string // Returned by GetLicense.  See NewLicense.
+	LicenseErr error  // If set, returned by GetLicense.
+	Version    string // Returned by GetVersion.  See NewVersion.
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrNotSupported is returned by the methods the test doubles do not implement.
+var ErrNotSupported = errors.New("szfake: not supported")
+
+var (
+	_ senzing.SzAbstractFactory = (*AbstractFactory)(nil)
+	_ senzing.SzConfigManager   = (*ConfigManager)(nil)
+	_ senzing.SzDiagnostic      = (*Diagnostic)(nil)
+	_ senzing.SzProduct         = (*Product)(nil)
+)
+
+// ----------------------------------------------------------------------------
+// AbstractFactory methods
+// ----------------------------------------------------------------------------
+
+// Close records that the factory was closed.
+func (factory *AbstractFactory) Close(ctx context.Context) error {
+	_ = ctx
+	factory.Closed = true
+
+	return nil
+}
+
+// CreateConfigManager returns the ConfigManager of the factory.
+func (factory *AbstractFactory) CreateConfigManager(ctx context.Context) (senzing.SzConfigManager, error) {
+	_ = ctx
+
+	if factory.CreateErr != nil {
+		return nil, factory.CreateErr
+	}
+
+	if factory.ConfigManager == nil {
+		factory.ConfigManager = &ConfigManager{
+			DefaultConfigID:    1,
+			DefaultConfigIDErr: nil,
+			DestroyErr:         nil,
+			Destroyed:          false,
+		}
+	}
+
+	return factory.ConfigManager, nil
+}
+
+// CreateDiagnostic returns the Diagnostic of the factory.
+func (factory *AbstractFactory) CreateDiagnostic(ctx context.Context) (senzing.SzDiagnostic, error) {
+	_ = ctx
+
+	if factory.CreateErr != nil {
+		return nil, factory.CreateErr
+	}
+
+	if factory.Diagnostic == nil {
+		factory.Diagnostic = &Diagnostic{
+			DestroyErr:        nil,
+			Destroyed:         false,
+			RepositoryInfo:    `{"dataStores":[{"id":"CORE","type":"sqlite3","location":"/tmp/sqlite/G2C.db"}]}`,
+			RepositoryInfoErr: nil,
+		}
+	}
+
+	return factory.Diagnostic, nil
+}
+
+// CreateEngine is not supported.
+func (factory *AbstractFactory) CreateEngine(ctx context.Context) (senzing.SzEngine, error) {
+	_ = ctx
+
+	return nil, ErrNotSupported
+}
+
+// CreateProduct returns the Product of the factory.
+func (factory *AbstractFactory) CreateProduct(ctx context.Context) (senzing.SzProduct, error) {
+	_ = ctx
+
+	if factory.CreateErr != nil {
+		return nil, factory.CreateErr
+	}
+
+	if factory.Product == nil {
+		factory.Product = &Product{
+			DestroyErr: nil,
+			Destroyed:  false,
+			License:    NewLicense(time.Now().AddDate(1, 0, 0), 0),
+			LicenseErr: nil,
+			Version:    NewVersion("4.0.0"),
+		}
+	}
+
+	return factory.Product, nil
+}
+
+// Reinitialize does nothing.
+func (factory *AbstractFactory) Reinitialize(ctx context.Context, configID int64) error {
+	_ = ctx
+	_ = configID
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// ConfigManager methods
+// ----------------------------------------------------------------------------
+
+// CreateConfigFromConfigID is not supported.
+func (configManager *ConfigManager) CreateConfigFromConfigID(
+	ctx context.Context,
+	configID int64,
+) (senzing.SzConfig, error) {
+	_ = ctx
+	_ = configID
+
+	return nil, ErrNotSupported
+}
+
+// CreateConfigFromString is not supported.
+func (configManager *ConfigManager) CreateConfigFromString(
+	ctx context.Context,
+	configDefinition string,
+) (senzing.SzConfig, error) {
+	_ = ctx
+	_ = configDefinition
+
+	return nil, ErrNotSupported
+}
+
+// CreateConfigFromTemplate is not supported.
+func (configManager *ConfigManager) CreateConfigFromTemplate(ctx context.Context) (senzing.SzConfig, error) {
+	_ = ctx
+
+	return nil, ErrNotSupported
+}
+
+// Destroy records that the config manager was destroyed and returns DestroyErr.
+func (configManager *ConfigManager) Destroy(ctx context.Context) error {
+	_ = ctx
+	configManager.Destroyed = true
+
+	return configManager.DestroyErr
+}
+
+// GetConfigRegistry is not supported.
+func (configManager *ConfigManager) GetConfigRegistry(ctx context.Context) (string, error) {
+	_ = ctx
+
+	return "", ErrNotSupported
+}
+
+// GetDefaultConfigID returns DefaultConfigID, or DefaultConfigIDErr if it is set.
+func (configManager *ConfigManager) GetDefaultConfigID(ctx context.Context) (int64, error) {
+	_ = ctx
+
+	return configManager.DefaultConfigID, configManager.DefaultConfigIDErr
+}
+
+// RegisterConfig is not supported.
+func (configManager *ConfigManager) RegisterConfig(
+	ctx context.Context,
+	configDefinition string,
+	configComment string,
+) (int64, error) {
+	_ = ctx
+	_ = configDefinition
+	_ = configComment
+
+	return 0, ErrNotSupported
+}
+
+// ReplaceDefaultConfigID is not supported.
+func (configManager *ConfigManager) ReplaceDefaultConfigID(
+	ctx context.Context,
+	currentDefaultConfigID int64,
+	newDefaultConfigID int64,
+) error {
+	_ = ctx
+	_ = currentDefaultConfigID
+	_ = newDefaultConfigID
+
+	return ErrNotSupported
+}
+
+// SetDefaultConfig is not supported.
+func (configManager *ConfigManager) SetDefaultConfig(
+	ctx context.Context,
+	configDefinition string,
+	configComment string,
+) (int64, error) {
+	_ = ctx
+	_ = configDefinition
+	_ = configComment
+
+	return 0, ErrNotSupported
+}
+
+// SetDefaultConfigID is not supported.
+func (configManager *ConfigManager) SetDefaultConfigID(ctx context.Context, configID int64) error {
+	_ = ctx
+	_ = configID
+
+	return ErrNotSupported
+}
+
+// ----------------------------------------------------------------------------
+// Diagnostic methods
+// ----------------------------------------------------------------------------
+
+// CheckRepositoryPerformance is not supported.
+func (diagnostic *Diagnostic) CheckRepositoryPerformance(ctx context.Context, secondsToRun int) (string, error) {
+	_ = ctx
+	_ = secondsToRun
+
+	return "", ErrNotSupported
+}
+
+// Destroy records that the diagnostic was destroyed and returns DestroyErr.
+func (diagnostic *Diagnostic) Destroy(ctx context.Context) error {
+	_ = ctx
+	diagnostic.Destroyed = true
+
+	return diagnostic.DestroyErr
+}
+
+// GetFeature is not supported.
+func (diagnostic *Diagnostic) GetFeature(ctx context.Context, featureID int64) (string, error) {
+	_ = ctx
+	_ = featureID
+
+	return "", ErrNotSupported
+}
+
+// GetRepositoryInfo returns RepositoryInfo, or RepositoryInfoErr if it is set.
+func (diagnostic *Diagnostic) GetRepositoryInfo(ctx context.Context) (string, error) {
+	_ = ctx
+
+	return diagnostic.RepositoryInfo, diagnostic.RepositoryInfoErr
+}
+
+// PurgeRepository is not supported.
+func (diagnostic *Diagnostic) PurgeRepository(ctx context.Context) error {
+	_ = ctx
+
+	return ErrNotSupported
+}
+
+// Reinitialize does nothing.
+func (diagnostic *Diagnostic) Reinitialize(ctx context.Context, configID int64) error {
+	_ = ctx
+	_ = configID
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Product methods
+// ----------------------------------------------------------------------------
+
+// Destroy records that the product was destroyed and returns DestroyErr.
+func (product *Product) Destroy(ctx context.Context) error {
+	_ = ctx
+	product.Destroyed = true
+
+	return product.DestroyErr
+}
+
+// GetLicense returns License, or LicenseErr if it is set.
+func (product *Product) GetLicense(ctx context.Context) (string, error) {
+	_ = ctx
+
+	return product.License, product.LicenseErr
+}
+
+// GetVersion returns Version.
+func (product *Product) GetVersion(ctx context.Context) (string, error) {
+	_ = ctx
+
+	return product.Version, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewLicense function returns the JSON of a Senzing license, as returned by
+SzProduct.GetLicense.
+
+Input
+  - expireDate: The day the license expires.
+  - recordLimit: The number of records the license allows.  Zero means unlimited.
+
+Output
+  - The license JSON document.
+*/
+func NewLicense(expireDate time.Time, recordLimit int64) string {
+	license := map[string]any{
+		"billing":      "YEARLY",
+		"contract":     "szfake",
+		"customer":     "szfake",
+		"expireDate":   expireDate.Format(time.DateOnly),
+		"issueDate":    expireDate.AddDate(-1, 0, 0).Format(time.DateOnly),
+		"licenseLevel": "STANDARD",
+		"licenseType":  "EVAL",
+		"recordLimit":  recordLimit,
+	}
+
+	result, err := json.Marshal(license)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(result)
+}
+
+/*
+The NewVersion function returns the JSON of a Senzing version, as returned by
+SzProduct.GetVersion.
+
+Input
+  - version: The Senzing version (e.g. "4.0.0").
+
+Output
+  - The version JSON document.
+*/
+func NewVersion(version string) string {
+	result, err := json.Marshal(map[string]string{
+		"BUILD_DATE":    "2025-01-01",
+		"BUILD_VERSION": version + ".00000",
+		"PRODUCT_NAME":  "Senzing SDK",
+		"VERSION":       version,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return string(result)
+}
@@ -0,0 +1,119 @@
+package szfake_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/check-self/checkself"
+	"github.com/senzing-garage/check-self/szfake"
+	"github.com/senzing-garage/go-observing/observerpb"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestAbstractFactory_CreateConfigManager(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	factory := &szfake.AbstractFactory{}
+	configManager, err := factory.CreateConfigManager(ctx)
+	require.NoError(test, err)
+	configID, err := configManager.GetDefaultConfigID(ctx)
+	require.NoError(test, err)
+	require.Equal(test, int64(1), configID)
+	require.NoError(test, configManager.Destroy(ctx))
+	require.True(test, factory.ConfigManager.Destroyed)
+	require.NoError(test, factory.Close(ctx))
+	require.True(test, factory.Closed)
+}
+
+func TestAbstractFactory_CreateErr(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	factory := &szfake.AbstractFactory{CreateErr: errors.New("no engine")}
+	_, err := factory.CreateConfigManager(ctx)
+	require.ErrorContains(test, err, "no engine")
+	_, err = factory.CreateDiagnostic(ctx)
+	require.ErrorContains(test, err, "no engine")
+	_, err = factory.CreateProduct(ctx)
+	require.ErrorContains(test, err, "no engine")
+}
+
+func TestAbstractFactory_CreateDiagnostic(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	factory := &szfake.AbstractFactory{}
+	diagnostic, err := factory.CreateDiagnostic(ctx)
+	require.NoError(test, err)
+	repositoryInfo, err := diagnostic.GetRepositoryInfo(ctx)
+	require.NoError(test, err)
+	require.Contains(test, repositoryInfo, `"dataStores"`)
+	_, err = diagnostic.GetFeature(ctx, 1)
+	require.ErrorIs(test, err, szfake.ErrNotSupported)
+	require.NoError(test, diagnostic.Destroy(ctx))
+	require.True(test, factory.Diagnostic.Destroyed)
+}
+
+func TestAbstractFactory_CreateEngine(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	factory := &szfake.AbstractFactory{}
+	_, err := factory.CreateEngine(ctx)
+	require.ErrorIs(test, err, szfake.ErrNotSupported)
+}
+
+func TestAbstractFactory_CreateProduct(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	factory := &szfake.AbstractFactory{}
+	product, err := factory.CreateProduct(ctx)
+	require.NoError(test, err)
+	license, err := product.GetLicense(ctx)
+	require.NoError(test, err)
+
+	response := &checkself.ProductLicenseResponse{}
+	require.NoError(test, json.Unmarshal([]byte(license), response))
+	require.Greater(test, response.ExpireDate, time.Now().Format(time.DateOnly))
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestNewLicense(test *testing.T) {
+	test.Parallel()
+
+	expireDate := time.Date(2030, time.June, 30, 0, 0, 0, 0, time.UTC)
+	response := &checkself.ProductLicenseResponse{}
+	require.NoError(test, json.Unmarshal([]byte(szfake.NewLicense(expireDate, 5000)), response))
+	require.Equal(test, "2030-06-30", response.ExpireDate)
+	require.Equal(test, "2029-06-30", response.IssueDate)
+	require.Equal(test, int64(5000), response.RecordLimit)
+}
+
+func TestNewVersion(test *testing.T) {
+	test.Parallel()
+
+	version := map[string]string{}
+	require.NoError(test, json.Unmarshal([]byte(szfake.NewVersion("4.1.0")), &version))
+	require.Equal(test, "4.1.0", version["VERSION"])
+	require.Equal(test, "4.1.0.00000", version["BUILD_VERSION"])
+}
+
+func TestObserver_UpdateObserver(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	observer := &szfake.Observer{}
+	_, err := observer.UpdateObserver(ctx, &observerpb.UpdateObserverRequest{Message: `{"messageId":"1"}`})
+	require.NoError(test, err)
+	require.Equal(test, []string{`{"messageId":"1"}`}, observer.Messages())
+
+	observer.UpdateErr = errors.New("observer failed")
+	_, err = observer.UpdateObserver(ctx, &observerpb.UpdateObserverRequest{Message: `{"messageId":"2"}`})
+	require.ErrorContains(test, err, "observer failed")
+	require.Len(test, observer.Messages(), 1)
+}